@@ -0,0 +1,13 @@
+// api/tick.go
+package handler
+
+import (
+	"net/http"
+)
+
+// Tick – точка входа Vercel-функции /api/tick.
+// В serverless нет фоновой проверки напоминаний, поэтому её раз в минуту
+// запускает внешний cron с заголовком Authorization: Bearer $CRON_SECRET.
+func Tick(w http.ResponseWriter, r *http.Request) {
+	tickHandler.ServeHTTP(w, r)
+}
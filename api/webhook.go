@@ -3,61 +3,33 @@ package handler
 
 import (
 	"net/http"
-	"os"
-	"time"
-	"log"
 
-	"github.com/joho/godotenv" 
-	"tg-bot/internal/bot"        // путь, который уже есть в ваших import'ах
-	"tg-bot/internal/reminders"
-	"tg-bot/internal/services"
-	"tg-bot/internal/utils"
-	"tg-bot/internal/config" 
+	"tg-bot/internal/app" // общая сборка приложения, как и в cmd/bot
+	"tg-bot/internal/bot"
 )
 
 // глобальный экземпляр приложения – инициализируется на cold-start
-var app *bot.BotApp
+var botApp *bot.BotApp
 
-func init() {
-	loc, _ := time.LoadLocation("Europe/Vilnius")
-
-	if err := godotenv.Load(); err != nil {
-		log.Println("Warning: .env file not found or failed to load, assuming environment vars are set manually")
-	}
+// tickHandler отправляет «сработавшие» напоминания по вызову внешнего cron
+var tickHandler http.Handler
 
-	// === 1. Загрузка конфигурации ===
-	cfg, err := config.LoadConfig()
+func init() {
+	a, cfg, err := app.New(true)
 	if err != nil {
-		log.Fatalf("Ошибка при загрузке конфигурации: %v", err)
-	}
-
-	// Проверяем, что обязательные переменные заданы
-	if cfg.BotToken == "" {
-		log.Fatal("TELEGRAM_BOT_TOKEN не задан в окружении")
-	}
-	if cfg.OpenWeatherAPIKey == "" {
-		log.Fatal("Погода не задан в окружении")
+		panic(err)         // Vercel покажет stack-trace в логах
 	}
 
-	storage   := reminders.NewMemoryStorage()
-	weather   := services.NewWeatherService(cfg.OpenWeatherAPIKey, cfg.Location)
-	currency  := services.NewCurrencyService()
-	utilsSvc  := utils.NewUtilsService()
-
-	app, err = bot.InitBot(
-		cfg.BotToken,
-		cfg.Location,
-		storage,
-		weather,
-		currency,
-		utilsSvc,
-	)
-	if err != nil {
-		panic(err)         // Vercel покажет stack-trace в логах
+	// Принимаем только апдейты с секретом. Вебхук и меню команд регистрируются
+	// один раз при деплое (bot -mode=setup), а не на каждом холодном старте.
+	if err := a.EnableWebhook(cfg.WebhookSecret); err != nil {
+		panic(err)
 	}
+	botApp = a
+	tickHandler = a.TickHandler(cfg.TickSecret)
 }
 
 // Handle – единственная точка входа Vercel-функции
 func Handle(w http.ResponseWriter, r *http.Request) {
-	app.ServeHTTP(w, r)   // метод, который мы добавили в BotApp
+	botApp.ServeHTTP(w, r)   // метод, который мы добавили в BotApp
 }
@@ -2,80 +2,95 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"github.com/joho/godotenv" 
-	"tg-bot/internal/bot"     // пакет с handler'ами
-	"tg-bot/internal/config"  // пакет для загрузки конфигурации
-	"tg-bot/internal/reminders" // in-memory хранилище напоминаний
-	"tg-bot/internal/services" // пакеты для API (погода, курс)
-	"tg-bot/internal/utils"
+	"tg-bot/internal/app" // общая сборка приложения для всех режимов запуска
 )
 
 func main() {
+	var (
+		mode     = flag.String("mode", "polling", "режим работы: polling, webhook или setup (регистрация вебхука и команд при деплое serverless)")
+		listen   = flag.String("listen", defaultListen(), "адрес HTTP-сервера в режиме webhook")
+		certFile = flag.String("tls-cert", "", "сертификат TLS (если TLS не терминируется прокси)")
+		keyFile  = flag.String("tls-key", "", "ключ TLS")
+		timeout  = flag.Duration("shutdown-timeout", 5*time.Second, "сколько ждать завершения работы после SIGTERM")
+	)
+	flag.Parse()
 
-	if err := godotenv.Load(); err != nil {
-		log.Println("Warning: .env file not found or failed to load, assuming environment vars are set manually")
-	}
-
-	// === 1. Загрузка конфигурации ===
-	cfg, err := config.LoadConfig()
+	// === 1-2. Конфигурация и инициализация «слоёв» приложения ===
+	botApp, cfg, err := app.New(*mode != "polling")
 	if err != nil {
-		log.Fatalf("Ошибка при загрузке конфигурации: %v", err)
+		log.Fatal(err)
 	}
 
-	// Проверяем, что обязательные переменные заданы
-	if cfg.BotToken == "" {
-		log.Fatal("TELEGRAM_BOT_TOKEN не задан в окружении")
-	}
-	if cfg.OpenWeatherAPIKey == "" {
-		log.Fatal("Погода не задан в окружении")
+	// Шаг деплоя serverless-версии: регистрируем вебхук и меню команд и выходим
+	if *mode == "setup" {
+		if err := app.Setup(botApp, cfg); err != nil {
+			log.Fatal(err)
+		}
+		log.Println("Вебхук и меню команд зарегистрированы")
+		return
 	}
 
-	// === 2. Инициализация «слоёв» приложения ===
+	// SIGTERM присылает Fly.io при деплое, SIGINT — Ctrl+C при локальном запуске
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// 2.1. Хранилище напоминаний (in-memory)
-	//      Заменить на БД: internal/reminders/storage.go
-	remStorage := reminders.NewMemoryStorage()
+	// === 3. Запуск фоновых задач ===
+
+	// 3.1. Ежеминутная проверка «календарных» напоминаний
+	botApp.StartReminderChecker(ctx)
 
-	utils := utils.NewUtilsService()
+	// 3.2. Синхронизация напоминаний с календарями CalDAV
+	botApp.StartCalendarSync(ctx)
 
-	// 2.2. Клиент OpenWeatherMap
-	weatherSvc := services.NewWeatherService(cfg.OpenWeatherAPIKey, cfg.Location)
+	// 3.3. Cron-задачи: утренняя рассылка в 08:00 и оповещения о качестве воздуха
+	botApp.StartMorningBriefCron()
 
-	// 2.3. Клиент для курса валют
-	currencySvc := services.NewCurrencyService()
+	// 3.4. Меню команд в Telegram — в фоне, чтобы не задерживать приём обновлений
+	go botApp.PublishCommands()
 
-	// 2.4. Инициализация Telebot с передачей зависимостей в handler-слой
-	botApp, err := bot.InitBot(cfg.BotToken, cfg.Location, remStorage, weatherSvc, currencySvc, utils)
-	if err != nil {
-		log.Fatalf("Ошибка при инициализации BotApp: %v", err)
+	// === 4. Приём обновлений ===
+	switch *mode {
+	case "polling":
+		log.Println("Bot started in long-polling mode...")
+		go botApp.StartLongPolling()
+		<-ctx.Done()
+
+	case "webhook":
+		log.Println("Bot started in webhook mode...")
+		opts := app.ServerOptions{Listen: *listen, CertFile: *certFile, KeyFile: *keyFile}
+		if err := app.RunWebhookServer(ctx, botApp, cfg, opts); err != nil {
+			log.Printf("Ошибка вебхук-сервера: %v", err)
+		}
+
+	default:
+		log.Fatalf("Неизвестный режим %q: используйте polling, webhook или setup", *mode)
 	}
 
-	// === 3. Запуск фоновых задач ===
+	// === 5. Корректная остановка ===
+	// Отменяем контекст (если сервер упал сам) и даём фоновым задачам доработать
+	stop()
+	log.Println("Завершаем работу...")
 
-	// 3.1. Ежеминутная проверка «календарных» напоминаний
-	go botApp.StartReminderChecker()
-
-	// 3.2. Cron-задача для утренней рассылки в 08:00 Europe/Vilnius
-	//      Внутри BotApp есть метод для этого
-	// botApp.StartMorningBriefCron()
-
-	// === 4. Запуск Telebot (Long Polling) ===
-	log.Println("Бот запущен, ожидаем обновления...")
-	app, err := bot.InitBot(
-		cfg.BotToken,
-		cfg.Location,
-		remStorage,
-		weatherSvc,
-		currencySvc,
-		utils,
-	)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
 
-	if err != nil {
-		panic(err)         // Vercel покажет stack-trace в логах
+	if err := botApp.Shutdown(shutdownCtx); err != nil {
+		log.Fatalf("Ошибка при остановке: %v", err)
 	}
+}
 
-	log.Println("Bot started in long-polling mode...")
-	app.StartLongPolling()
-}
\ No newline at end of file
+// defaultListen берёт порт из PORT (его выставляют Fly.io и другие платформы)
+func defaultListen() string {
+	if port := os.Getenv("PORT"); port != "" {
+		return ":" + port
+	}
+	return ":8080"
+}
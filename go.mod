@@ -3,9 +3,10 @@ module tg-bot
 go 1.22.6
 
 require (
-	github.com/joho/godotenv v1.5.1 // indirect
-	github.com/robfig/cron/v3 v3.0.1 // indirect
-	golang.org/x/net v0.33.0 // indirect
-	gopkg.in/telebot.v4 v4.0.0-beta.5 // indirect
-	resty.dev/v3 v3.0.0-beta.3 // indirect
+	github.com/joho/godotenv v1.5.1
+	github.com/robfig/cron/v3 v3.0.1
+	gopkg.in/telebot.v4 v4.0.0-beta.5
+	resty.dev/v3 v3.0.0-beta.3
 )
+
+require golang.org/x/net v0.33.0 // indirect
@@ -0,0 +1,81 @@
+package advice
+
+// Conditions — погодные условия, по которым подбираются советы
+type Conditions struct {
+	Temp      float64 // текущая температура, °C
+	FeelsLike float64 // самая низкая ощущаемая температура за день, °C (для правила холода)
+	MaxFeels  float64 // самая высокая ощущаемая температура за день, °C (для правила жары)
+	MinTemp   float64 // минимальная температура за день, °C
+	Pop       float64 // вероятность осадков, 0..1
+	WindSpeed float64 // скорость ветра, м/с
+	UVI       float64 // УФ-индекс
+	Rain      float64 // осадки, мм
+	Snow      float64 // снег, мм
+}
+
+// Thresholds — пороги срабатывания правил, настраиваются для каждого чата
+type Thresholds struct {
+	UmbrellaPop  float64 // вероятность осадков, с которой советуем зонт, 0..1
+	SunscreenUVI float64 // УФ-индекс, с которого нужен крем
+	ColdFeels    float64 // ощущаемая температура, ниже которой советуем одеться теплее
+	HeatFeels    float64 // ощущаемая температура, выше которой предупреждаем о жаре
+	WindSpeed    float64 // скорость ветра, с которой предупреждаем о ветре
+}
+
+// DefaultThresholds возвращает пороги по умолчанию
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		UmbrellaPop:  0.4,
+		SunscreenUVI: 3,
+		ColdFeels:    0,
+		HeatFeels:    28,
+		WindSpeed:    10,
+	}
+}
+
+// rule — одно правило: условие срабатывания и ключ совета в каталоге сообщений
+type rule struct {
+	applies func(c Conditions, t Thresholds) bool
+	key     string
+}
+
+var rules = []rule{
+	{
+		applies: func(c Conditions, t Thresholds) bool { return c.Pop >= t.UmbrellaPop || c.Rain > 0 },
+		key:     "advice.umbrella",
+	},
+	{
+		applies: func(c Conditions, t Thresholds) bool { return c.UVI >= t.SunscreenUVI },
+		key:     "advice.sunscreen",
+	},
+	{
+		// Гололёд: температура около нуля и осадки (или были осадки)
+		applies: func(c Conditions, t Thresholds) bool {
+			return c.MinTemp <= 1 && c.Temp >= -5 && (c.Pop >= t.UmbrellaPop || c.Rain > 0 || c.Snow > 0)
+		},
+		key: "advice.ice",
+	},
+	{
+		applies: func(c Conditions, t Thresholds) bool { return c.FeelsLike <= t.ColdFeels },
+		key:     "advice.cold",
+	},
+	{
+		applies: func(c Conditions, t Thresholds) bool { return c.MaxFeels >= t.HeatFeels },
+		key:     "advice.heat",
+	},
+	{
+		applies: func(c Conditions, t Thresholds) bool { return c.WindSpeed >= t.WindSpeed },
+		key:     "advice.wind",
+	},
+}
+
+// Advise возвращает ключи советов (см. пакет i18n), подходящих под условия, в порядке важности
+func Advise(c Conditions, t Thresholds) []string {
+	var res []string
+	for _, r := range rules {
+		if r.applies(c, t) {
+			res = append(res, r.key)
+		}
+	}
+	return res
+}
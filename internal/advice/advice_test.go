@@ -0,0 +1,43 @@
+package advice
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAdvise(t *testing.T) {
+	def := DefaultThresholds()
+	mild := Conditions{Temp: 18, FeelsLike: 15, MaxFeels: 20, MinTemp: 12, WindSpeed: 3, UVI: 1}
+
+	tests := []struct {
+		name string
+		c    Conditions
+		t    Thresholds
+		want []string
+	}{
+		{"тихий день", mild, def, nil},
+		{"зонт по вероятности", with(mild, func(c *Conditions) { c.Pop = 0.6 }), def, []string{"advice.umbrella"}},
+		{"зонт по осадкам", with(mild, func(c *Conditions) { c.Rain = 0.3 }), def, []string{"advice.umbrella"}},
+		{"крем", with(mild, func(c *Conditions) { c.UVI = 6 }), def, []string{"advice.sunscreen"}},
+		// Утро холодное, днём жара: срабатывают оба правила — по минимуму и по максимуму
+		{"холодное утро и жаркий день", with(mild, func(c *Conditions) { c.FeelsLike, c.MaxFeels = -2, 31 }), def,
+			[]string{"advice.cold", "advice.heat"}},
+		{"жара", with(mild, func(c *Conditions) { c.FeelsLike, c.MaxFeels = 22, 30 }), def, []string{"advice.heat"}},
+		{"холод", with(mild, func(c *Conditions) { c.FeelsLike, c.MaxFeels = -8, -3 }), def, []string{"advice.cold"}},
+		{"ветер", with(mild, func(c *Conditions) { c.WindSpeed = 12 }), def, []string{"advice.wind"}},
+		{"гололёд", Conditions{Temp: 0, FeelsLike: -4, MaxFeels: -1, MinTemp: -2, Snow: 1}, def,
+			[]string{"advice.ice", "advice.cold"}},
+		{"свои пороги", with(mild, func(c *Conditions) { c.MaxFeels = 20 }),
+			Thresholds{UmbrellaPop: 1, SunscreenUVI: 11, ColdFeels: -30, HeatFeels: 20, WindSpeed: 30}, []string{"advice.heat"}},
+	}
+	for _, tt := range tests {
+		if got := Advise(tt.c, tt.t); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Advise = %v, ожидалось %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func with(c Conditions, change func(c *Conditions)) Conditions {
+	change(&c)
+	return c
+}
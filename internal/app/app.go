@@ -0,0 +1,96 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/joho/godotenv"
+
+	"tg-bot/internal/bot"       // пакет с handler'ами
+	"tg-bot/internal/config"    // пакет для загрузки конфигурации
+	"tg-bot/internal/conversation" // состояние многошаговых диалогов
+	"tg-bot/internal/expenses"  // траты
+	"tg-bot/internal/kv"        // постоянное хранилище (файл или Upstash)
+	"tg-bot/internal/reminders" // хранилище напоминаний
+	"tg-bot/internal/services"  // пакеты для API (погода, курс)
+	"tg-bot/internal/settings"  // настройки чатов (местоположение, подписки)
+)
+
+// New загружает конфигурацию и собирает все слои приложения.
+// Используется всеми режимами запуска: long polling, вебхук-сервер и serverless;
+// webhook — апдейты будут приходить по HTTP (вебхук-сервер и serverless).
+func New(webhook bool) (*bot.BotApp, *config.Config, error) {
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found or failed to load, assuming environment vars are set manually")
+	}
+
+	// === 1. Загрузка конфигурации ===
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка при загрузке конфигурации: %w", err)
+	}
+
+	// Проверяем, что обязательные переменные заданы
+	if cfg.BotToken == "" {
+		return nil, nil, errors.New("TG_BOT_TOKEN не задан в окружении")
+	}
+	if cfg.OpenWeatherAPIKey == "" {
+		return nil, nil, errors.New("OPEN_API_KEY не задан в окружении")
+	}
+
+	// === 2. Инициализация «слоёв» приложения ===
+
+	// 2.1. Хранилища напоминаний и настроек чатов
+	store, err := openStore(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	remStorage := reminders.NewMemoryStorage()
+	geoStorage := reminders.NewMemoryGeoStorage()
+	convStorage := conversation.NewMemoryStorage()
+	expenseStorage := expenses.NewMemoryStorage()
+	settingsStorage := settings.NewMemoryStorage()
+	if store != nil {
+		remStorage = reminders.NewKVStorage(store)
+		geoStorage = reminders.NewKVGeoStorage(store)
+		convStorage = conversation.NewKVStorage(store)
+		expenseStorage = expenses.NewKVStorage(store)
+		settingsStorage = settings.NewKVStorage(store)
+	}
+
+	// 2.2. Клиент OpenWeatherMap
+	weatherSvc := services.NewWeatherService(cfg.OpenWeatherAPIKey, cfg.Location)
+
+	// 2.3. Клиент для курса валют
+	currencySvc := services.NewCurrencyService()
+
+	// 2.4. Инициализация Telebot с передачей зависимостей в handler-слой
+	botApp, err := bot.InitBot(cfg.BotToken, cfg.Location, remStorage, geoStorage, weatherSvc, currencySvc, settingsStorage, convStorage, expenseStorage, webhook)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка при инициализации BotApp: %w", err)
+	}
+	botApp.TrustCalendarHosts(cfg.CalDAVTrusted)
+
+	return botApp, cfg, nil
+}
+
+// openStore выбирает постоянное хранилище по конфигурации:
+// Upstash/Vercel KV для serverless, файл для одного процесса,
+// nil — данные хранятся только в памяти
+func openStore(cfg *config.Config) (kv.Store, error) {
+	switch {
+	case cfg.KVURL != "":
+		return kv.NewUpstashStore(cfg.KVURL, cfg.KVToken), nil
+	case cfg.DataFile != "":
+		store, err := kv.NewFileStore(cfg.DataFile)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при открытии хранилища: %w", err)
+		}
+		return store, nil
+	default:
+		log.Println("Warning: DATA_FILE и KV_REST_API_URL не заданы, данные хранятся только в памяти")
+		return nil, nil
+	}
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"tg-bot/internal/bot"
+	"tg-bot/internal/config"
+)
+
+// shutdownTimeout — сколько ждём завершения активных запросов при остановке сервера
+const shutdownTimeout = 10 * time.Second
+
+// ServerOptions — параметры HTTP-сервера для режима вебхука
+type ServerOptions struct {
+	Listen   string // адрес, на котором слушаем, например ":8080"
+	CertFile string // сертификат TLS; пусто — обычный HTTP (TLS терминирует прокси)
+	KeyFile  string // ключ TLS
+}
+
+// RunWebhookServer регистрирует вебхук и обслуживает его до отмены ctx,
+// после чего корректно завершает активные запросы
+func RunWebhookServer(ctx context.Context, botApp *bot.BotApp, cfg *config.Config, opts ServerOptions) error {
+	if cfg.WebhookURL == "" {
+		return errors.New("для режима вебхука нужен WEBHOOK_URL")
+	}
+	if err := botApp.EnableWebhook(cfg.WebhookSecret); err != nil {
+		return err
+	}
+	if err := botApp.RegisterWebhook(cfg.WebhookURL); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(webhookPath(cfg.WebhookURL), botApp)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+
+	srv := &http.Server{
+		Addr:              opts.Listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       2 * time.Minute,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("Вебхук-сервер слушает %s", opts.Listen)
+		if opts.CertFile != "" {
+			errCh <- srv.ListenAndServeTLS(opts.CertFile, opts.KeyFile)
+		} else {
+			errCh <- srv.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Println("Останавливаем вебхук-сервер...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	return srv.Shutdown(shutdownCtx)
+}
+
+// Setup регистрирует вебхук и публикует меню команд. Для serverless это шаг деплоя
+// (bot -mode=setup): функция на каждом холодном старте только проверяет секрет.
+func Setup(botApp *bot.BotApp, cfg *config.Config) error {
+	if err := botApp.EnableWebhook(cfg.WebhookSecret); err != nil {
+		return err
+	}
+	if err := botApp.RegisterWebhook(cfg.WebhookURL); err != nil {
+		return err
+	}
+	botApp.PublishCommands()
+	return nil
+}
+
+// webhookPath возвращает путь из публичного адреса вебхука, по умолчанию «/»
+func webhookPath(publicURL string) string {
+	u, err := url.Parse(publicURL)
+	if err != nil || u.Path == "" {
+		return "/"
+	}
+	return u.Path
+}
@@ -0,0 +1,148 @@
+package bot
+
+import (
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/advice"
+	"tg-bot/internal/settings"
+)
+
+// conditionsFrom собирает условия для советов из текущей погоды и прогноза на сегодня
+func conditionsFrom(res oneDailyWeatherRes) advice.Conditions {
+	cur := res.Current
+	cond := advice.Conditions{
+		Temp:      cur.Temp,
+		FeelsLike: cur.FeelsLike,
+		MaxFeels:  cur.FeelsLike,
+		MinTemp:   cur.Temp,
+		WindSpeed: cur.Wind_speed,
+		UVI:       cur.Uvi,
+		Rain:      cur.Rain["1h"],
+		Snow:      cur.Snow["1h"],
+	}
+
+	if len(res.Daily) > 0 {
+		today := res.Daily[0]
+		cond.MinTemp = today.Temp.Min
+		cond.Pop = today.Pop
+		cond.Rain += today.Rain
+		cond.Snow += today.Snow
+		cond.FeelsLike = min(cond.FeelsLike, today.FeelsLike.Morn, today.FeelsLike.Day, today.FeelsLike.Eve)
+		cond.MaxFeels = max(cond.MaxFeels, today.FeelsLike.Morn, today.FeelsLike.Day, today.FeelsLike.Eve)
+		cond.WindSpeed = max(cond.WindSpeed, today.WindSpeed)
+		cond.UVI = max(cond.UVI, today.Uvi)
+	}
+
+	return cond
+}
+
+// adviceBlock формирует блок советов для ответа о погоде; пустая строка — советовать нечего
+func (app *BotApp) adviceBlock(chatID int64, p chatPrefs, res oneDailyWeatherRes) string {
+	s, err := app.settings.Get(chatID)
+	if err != nil {
+		log.Printf("Не удалось получить настройки чата %d: %v", chatID, err)
+	}
+
+	cond := conditionsFrom(res)
+	if p.imperial() {
+		// Погода пришла в °F и милях в час, а пороги заданы в °C и м/с
+		cond.Temp, cond.WindSpeed = p.toMetric(cond.Temp, cond.WindSpeed)
+		cond.FeelsLike, _ = p.toMetric(cond.FeelsLike, 0)
+		cond.MaxFeels, _ = p.toMetric(cond.MaxFeels, 0)
+		cond.MinTemp, _ = p.toMetric(cond.MinTemp, 0)
+	}
+
+	keys := advice.Advise(cond, s.AdviceThresholds())
+	if len(keys) == 0 {
+		return ""
+	}
+
+	tips := make([]string, len(keys))
+	for i, key := range keys {
+		tips[i] = p.t(key)
+	}
+	return p.t("advice.title") + strings.Join(tips, "\n") + "\n"
+}
+
+// adviceLimits — допустимые значения порогов /advice в единицах команды:
+// проценты, УФ-индекс, °C и м/с
+var adviceLimits = map[string][2]float64{
+	"umbrella":  {0, 100},
+	"sunscreen": {0, 15},
+	"cold":      {-60, 60},
+	"heat":      {-60, 60},
+	"wind":      {0, 60},
+}
+
+// handleAdvice показывает и меняет пороги советов:
+// /advice, /advice umbrella 50, /advice reset
+func (app *BotApp) handleAdvice(c tele.Context) error {
+	args := strings.Fields(c.Message().Payload)
+	chatID := c.Chat().ID
+	p := app.prefsFor(c)
+
+	if len(args) == 1 && args[0] == "reset" {
+		if err := app.updateSettings(chatID, func(s *settings.Settings) { s.Advice = nil }); err != nil {
+			return c.Send(p.t("settings.save_failed"))
+		}
+		return c.Send(p.t("advice.reset"))
+	}
+
+	if len(args) == 2 {
+		rule := args[0]
+		limits, ok := adviceLimits[rule]
+		if !ok {
+			return c.Send(p.t("advice.unknown_rule"))
+		}
+		value, err := strconv.ParseFloat(strings.Replace(args[1], ",", ".", 1), 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			return c.Send(p.t("advice.not_number"))
+		}
+		if value < limits[0] || value > limits[1] {
+			return c.Send(p.t("advice.out_of_range", rule, p.number(limits[0], 0), p.number(limits[1], 0)))
+		}
+
+		var conflict *advice.Thresholds
+		err = app.updateSettings(chatID, func(s *settings.Settings) {
+			t := s.AdviceThresholds()
+			switch rule {
+			case "umbrella":
+				t.UmbrellaPop = value / 100
+			case "sunscreen":
+				t.SunscreenUVI = value
+			case "cold":
+				t.ColdFeels = value
+			case "heat":
+				t.HeatFeels = value
+			case "wind":
+				t.WindSpeed = value
+			}
+			if t.ColdFeels >= t.HeatFeels {
+				conflict = &t
+				return
+			}
+			s.Advice = &t
+		})
+		if err != nil {
+			return c.Send(p.t("settings.save_failed"))
+		}
+		if conflict != nil {
+			return c.Send(p.t("advice.cold_above_heat", p.number(conflict.ColdFeels, 1), p.number(conflict.HeatFeels, 1)))
+		}
+	} else if len(args) != 0 {
+		return c.Send(p.t("advice.usage"))
+	}
+
+	s, err := app.settings.Get(chatID)
+	if err != nil {
+		log.Printf("Не удалось получить настройки чата %d: %v", chatID, err)
+	}
+	t := s.AdviceThresholds()
+
+	return c.Send(p.t("advice.thresholds", t.UmbrellaPop*100, t.SunscreenUVI, t.ColdFeels, t.HeatFeels, t.WindSpeed))
+}
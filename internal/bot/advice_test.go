@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"testing"
+
+	"tg-bot/internal/advice"
+	"tg-bot/internal/i18n"
+)
+
+func TestHandleAdvice(t *testing.T) {
+	def := advice.DefaultThresholds()
+
+	tests := []struct {
+		name    string
+		payload string
+		reply   string
+		want    advice.Thresholds
+		saved   bool // должны ли пороги сохраниться в настройках
+	}{
+		{"неизвестное правило", "rain 50", i18n.T("ru", "advice.unknown_rule"), def, false},
+		{"не число", "umbrella много", i18n.T("ru", "advice.not_number"), def, false},
+		{"бесконечность", "wind Inf", i18n.T("ru", "advice.not_number"), def, false},
+		{"вероятность больше 100", "umbrella 150", i18n.T("ru", "advice.out_of_range", "umbrella", "0", "100"), def, false},
+		{"огромный ветер", "wind 1e9", i18n.T("ru", "advice.out_of_range", "wind", "0", "60"), def, false},
+		{"отрицательный УФ", "sunscreen -1", i18n.T("ru", "advice.out_of_range", "sunscreen", "0", "15"), def, false},
+		{"холод выше жары", "cold 40", i18n.T("ru", "advice.cold_above_heat", "40,0", "28,0"), def, false},
+		{"зонт", "umbrella 50,5", "", func() advice.Thresholds { t := def; t.UmbrellaPop = 0.505; return t }(), true},
+		{"жара", "heat 25", "", func() advice.Thresholds { t := def; t.HeatFeels = 25; return t }(), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, tg := newTestBot(t)
+			send(app, privateChat, testUser, "/advice "+tt.payload)
+
+			texts := tg.texts()
+			if len(texts) != 1 {
+				t.Fatalf("отправлено %d сообщений, ожидалось одно: %q", len(texts), texts)
+			}
+			if tt.reply != "" && texts[0] != tt.reply {
+				t.Errorf("ответ %q, ожидался %q", texts[0], tt.reply)
+			}
+
+			s, err := app.settings.Get(privateChat.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (s.Advice != nil) != tt.saved {
+				t.Errorf("пороги сохранены: %v, ожидалось %v", s.Advice != nil, tt.saved)
+			}
+			if got := s.AdviceThresholds(); got != tt.want {
+				t.Errorf("пороги %+v, ожидались %+v", got, tt.want)
+			}
+		})
+	}
+}
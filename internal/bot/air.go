@@ -0,0 +1,120 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/services"
+	"tg-bot/internal/settings"
+)
+
+// aqiLabel возвращает человекочитаемое описание индекса качества воздуха
+func aqiLabel(p chatPrefs, aqi int) string {
+	if aqi < 1 || aqi > 5 {
+		return p.t("weather.nodata")
+	}
+	return p.t(fmt.Sprintf("air.aqi%d", aqi))
+}
+
+// formatAirPollution формирует сообщение о качестве воздуха
+func formatAirPollution(p chatPrefs, air services.AirPollution) string {
+	return p.t("air.report", aqiLabel(p, air.AQI), air.AQI, p.number(air.PM25, 1), p.number(air.PM10, 1), p.number(air.O3, 1))
+}
+
+// handleAir показывает качество воздуха для сохранённого местоположения
+func (app *BotApp) handleAir(c tele.Context) error {
+	lat, lon := app.chatCoords(c.Chat().ID)
+
+	air, err := app.weatherSvc.GetAirPollution(lat, lon)
+	if err != nil {
+		return c.Send(err.Error())
+	}
+
+	return c.Send(formatAirPollution(app.prefsFor(c), air))
+}
+
+// handleAirAlert настраивает порог оповещения о качестве воздуха: /air_alert 3 или /air_alert off
+func (app *BotApp) handleAirAlert(c tele.Context) error {
+	arg := strings.TrimSpace(c.Message().Payload)
+	p := app.prefsFor(c)
+
+	level := 0
+	if arg != "off" {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 || n > 4 {
+			return c.Send(p.t("air.alert_usage"))
+		}
+		level = n
+	}
+
+	err := app.updateSettings(c.Chat().ID, func(s *settings.Settings) {
+		s.AirAlert = level
+		s.AirAlerted = false
+	})
+	if err != nil {
+		return c.Send(p.t("settings.save_failed"))
+	}
+
+	if level == 0 {
+		return c.Send(p.t("air.alert_off"))
+	}
+	return c.Send(p.t("air.alert_on", level, aqiLabel(p, level)))
+}
+
+// checkAirAlerts проверяет качество воздуха для чатов с включённым оповещением.
+// Оповещение отправляется один раз, пока индекс снова не опустится до порога.
+func (app *BotApp) checkAirAlerts() {
+	all, err := app.settings.ListAll()
+	if err != nil {
+		log.Printf("Не удалось получить список настроек: %v", err)
+		return
+	}
+
+	for _, s := range all {
+		if s.AirAlert == 0 {
+			continue
+		}
+
+		lat, lon := app.chatCoords(s.ChatID)
+		air, err := app.weatherSvc.GetAirPollution(lat, lon)
+		if err != nil {
+			log.Printf("Не удалось получить качество воздуха для чата %d: %v", s.ChatID, err)
+			continue
+		}
+
+		exceeded := air.AQI > s.AirAlert
+		if exceeded == s.AirAlerted {
+			continue
+		}
+
+		// Флаг сохраняется до отправки: если сохранить его не удалось, оповещение
+		// не отправляется, иначе оно повторялось бы каждый час. Меняется только флаг
+		// (s прочитаны до запросов к API), и только если его ещё не сменила
+		// одновременная проверка, — так оповещение не уйдёт дважды.
+		changed := false
+		err = app.updateSettings(s.ChatID, func(cur *settings.Settings) {
+			if cur.AirAlert != 0 && cur.AirAlerted != exceeded {
+				cur.AirAlerted, changed = exceeded, true
+			}
+		})
+		if err != nil {
+			log.Printf("Оповещение о воздухе в чат %d не отправлено: не удалось сохранить его состояние: %v", s.ChatID, err)
+			continue
+		}
+		if !changed || !exceeded {
+			continue
+		}
+
+		p := prefsOf(s, app.location)
+		msg := p.t("air.alert") + formatAirPollution(p, air)
+		if _, err := app.bot.Send(&tele.Chat{ID: s.ChatID}, msg); err != nil {
+			log.Printf("Не удалось отправить оповещение о воздухе в чат %d: %v", s.ChatID, err)
+			// Снимаем флаг, чтобы следующая проверка попробовала снова
+			app.updateSettings(s.ChatID, func(cur *settings.Settings) { cur.AirAlerted = false })
+		}
+	}
+}
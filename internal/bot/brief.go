@@ -0,0 +1,195 @@
+package bot
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/i18n"
+	"tg-bot/internal/reminders"
+	"tg-bot/internal/services"
+	"tg-bot/internal/settings"
+)
+
+// StartMorningBriefCron настраивает cron-задачи: ежеминутную проверку, кому пора
+// отправить утреннюю сводку, и ежечасную проверку качества воздуха для оповещений
+func (app *BotApp) StartMorningBriefCron() {
+	c := cron.New(cron.WithLocation(app.location))
+	app.cron = c
+
+	// Время сводки и часовой пояс у каждого чата свои, поэтому проверяем каждую минуту
+	if _, err := c.AddFunc("* * * * *", func() { app.sendMorningBrief(time.Now()) }); err != nil {
+		log.Fatalf("Не удалось добавить cron-задачу: %v", err)
+	}
+	if _, err := c.AddFunc("@hourly", app.checkAirAlerts); err != nil {
+		log.Fatalf("Не удалось добавить cron-задачу: %v", err)
+	}
+	c.Start()
+}
+
+// briefWindow — сколько после времени сводки её ещё можно отправить, если проверка
+// в нужную минуту не состоялась: в serverless внешний cron может пропустить вызов
+const briefWindow = 30 * time.Minute
+
+// sendMorningBrief рассылает утреннюю сводку подписчикам, у которых по их
+// часовому поясу наступило выбранное время сводки, и возвращает число отправленных.
+// Каждому чату сводка уходит не больше раза в день, сколько бы проверок ни было.
+func (app *BotApp) sendMorningBrief(now time.Time) int {
+	all, err := app.settings.ListAll()
+	if err != nil {
+		log.Printf("Не удалось получить список подписчиков: %v", err)
+		return 0
+	}
+
+	sent := 0
+	for _, s := range all {
+		local := now.In(s.TimeLocation(app.location))
+		day := local.Format(time.DateOnly)
+		if !s.Brief || s.BriefSent == day || !briefDue(local, s.BriefAt()) {
+			continue
+		}
+
+		// День отмечается до отправки под блокировкой настроек чата: одновременные
+		// проверки (повторный вызов /api/tick) не отправят сводку дважды
+		claimed := false
+		err := app.updateSettings(s.ChatID, func(cur *settings.Settings) {
+			if cur.Brief && cur.BriefSent != day {
+				cur.BriefSent, claimed = day, true
+			}
+		})
+		if err != nil || !claimed {
+			continue
+		}
+
+		body := app.buildMorningBrief(s.ChatID, s.BriefAir)
+		if _, err := app.bot.Send(&tele.Chat{ID: s.ChatID}, body); err != nil {
+			log.Printf("Не удалось отправить утреннюю сводку пользователю %d: %v", s.ChatID, err)
+			continue
+		}
+		sent++
+	}
+	return sent
+}
+
+// briefDue сообщает, что время сводки at (ЧЧ:ММ) сегодня наступило не больше briefWindow назад
+func briefDue(local time.Time, at string) bool {
+	t, err := time.Parse("15:04", at)
+	if err != nil {
+		return false
+	}
+	due := time.Date(local.Year(), local.Month(), local.Day(), t.Hour(), t.Minute(), 0, 0, local.Location())
+	return !local.Before(due) && local.Sub(due) < briefWindow
+}
+
+// buildMorningBrief собирает текст сводки для чата; недоступные разделы пропускаются
+func (app *BotApp) buildMorningBrief(chatID int64, withAir bool) string {
+	p := app.prefs(chatID)
+	now := time.Now().In(p.loc)
+
+	var b strings.Builder
+	b.WriteString(p.t("brief.greeting", i18n.DayName(p.lang, now), i18n.DayMonth(p.lang, now)))
+
+	// 1) Погода
+	if fullRes, err := app.weatherFor(chatID); err != nil {
+		log.Printf("Ошибка при получении погоды: %v", err)
+	} else {
+		cur := fullRes.Current
+		desc := p.t("weather.nodata")
+		if len(cur.Weather) > 0 {
+			desc = cur.Weather[0].Description
+		}
+		b.WriteString(p.t("brief.weather", desc, p.temp(cur.Temp), p.temp(cur.FeelsLike)))
+		b.WriteString(app.adviceBlock(chatID, p, fullRes))
+	}
+
+	// 2) Курсы валют
+	for _, t := range rateTypes([]services.CurrencyType{services.USD, services.EUR, services.RUB}, p.currency) {
+		line, err := app.rateLine(p, t, p.currency)
+		if err != nil {
+			log.Printf("Ошибка при получении курса валют: %v", err)
+			continue
+		}
+		b.WriteString("💱 " + line + "\n")
+	}
+
+	// 3) Качество воздуха (по желанию пользователя)
+	if withAir {
+		lat, lon := app.chatCoords(chatID)
+		if air, err := app.weatherSvc.GetAirPollution(lat, lon); err != nil {
+			log.Printf("Ошибка при получении качества воздуха: %v", err)
+		} else {
+			b.WriteString("\n" + formatAirPollution(p, air))
+		}
+	}
+
+	// 4) Напоминания на сегодня: в группе сводка общая, поэтому в ней видны
+	// напоминания всех участников
+	var today strings.Builder
+	for _, r := range app.chatReminders(chatID) {
+		at := r.Time.In(p.loc)
+		if r.State != reminders.StatePending || at.Before(now) || at.Format(time.DateOnly) != now.Format(time.DateOnly) {
+			continue
+		}
+		line := p.clock(at) + " — " + r.Text
+		if r.Mention != "" {
+			line += p.t("remind.for", r.Mention)
+		}
+		today.WriteString("• " + line + "\n")
+	}
+	if today.Len() > 0 {
+		b.WriteString("\n" + p.t("brief.reminders") + today.String())
+	}
+
+	return b.String()
+}
+
+// handleSubscribe включает утреннюю сводку: /subscribe
+func (app *BotApp) handleSubscribe(c tele.Context) error {
+	m := c.Message()
+	p := app.prefsFor(c)
+	var at, zone string
+	err := app.updateSettings(m.Chat.ID, func(s *settings.Settings) {
+		s.Brief = true
+		at, zone = s.BriefAt(), s.TimeLocation(app.location).String()
+	})
+	if err != nil {
+		return c.Send(p.t("brief.subscribe_failed"))
+	}
+	return c.Send(p.t("brief.subscribed", at, zone))
+}
+
+// handleUnsubscribe выключает утреннюю сводку: /unsubscribe
+func (app *BotApp) handleUnsubscribe(c tele.Context) error {
+	m := c.Message()
+	p := app.prefsFor(c)
+	if err := app.updateSettings(m.Chat.ID, func(s *settings.Settings) { s.Brief = false }); err != nil {
+		return c.Send(p.t("brief.unsubscribe_failed"))
+	}
+	return c.Send(p.t("brief.unsubscribed"))
+}
+
+// handleBriefAir включает или выключает качество воздуха в сводке: /brief_air on|off
+func (app *BotApp) handleBriefAir(c tele.Context) error {
+	m := c.Message()
+	p := app.prefsFor(c)
+	var enabled bool
+	switch strings.TrimSpace(m.Payload) {
+	case "on":
+		enabled = true
+	case "off":
+		enabled = false
+	default:
+		return c.Send(p.t("brief.air_usage"))
+	}
+
+	if err := app.updateSettings(m.Chat.ID, func(s *settings.Settings) { s.BriefAir = enabled }); err != nil {
+		return c.Send(p.t("settings.save_failed"))
+	}
+	if enabled {
+		return c.Send(p.t("brief.air_on"))
+	}
+	return c.Send(p.t("brief.air_off"))
+}
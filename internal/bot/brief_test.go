@@ -0,0 +1,59 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"tg-bot/internal/settings"
+)
+
+func TestBriefDue(t *testing.T) {
+	at := func(clock string) time.Time {
+		t, _ := time.ParseInLocation("2006-01-02 15:04", "2025-06-20 "+clock, testZone)
+		return t
+	}
+
+	tests := []struct {
+		now   time.Time
+		brief string
+		want  bool
+	}{
+		{at("07:59"), "08:00", false},
+		{at("08:00"), "08:00", true},
+		{at("08:29"), "08:00", true},
+		{at("08:30"), "08:00", false},
+		{at("23:50"), "23:45", true},
+		{at("00:05"), "23:45", false},
+		{at("08:00"), "8 утра", false},
+	}
+
+	for _, tt := range tests {
+		if got := briefDue(tt.now, tt.brief); got != tt.want {
+			t.Errorf("briefDue(%s, %s) = %v, ожидалось %v", tt.now.Format("15:04"), tt.brief, got, tt.want)
+		}
+	}
+}
+
+func TestMorningBriefOncePerDay(t *testing.T) {
+	app, tg := newTestBot(t)
+	now, _ := time.ParseInLocation("2006-01-02 15:04", "2025-06-20 08:10", testZone)
+
+	subscribers := []settings.Settings{
+		{ChatID: 1, Brief: true, BriefSent: "2025-06-20"},  // уже отправлена сегодня
+		{ChatID: 2, Brief: true, BriefTime: "09:00"},       // время ещё не наступило
+		{ChatID: 3, Brief: true, BriefTime: "07:00"},       // окно отправки прошло
+		{ChatID: 4, Brief: false, BriefSent: "2025-06-19"}, // не подписан
+	}
+	for _, s := range subscribers {
+		if err := app.settings.Save(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if sent := app.sendMorningBrief(now); sent != 0 {
+		t.Errorf("отправлено %d сводок, ожидалось 0", sent)
+	}
+	if texts := tg.texts(); len(texts) != 0 {
+		t.Errorf("отправлены сообщения: %q", texts)
+	}
+}
@@ -0,0 +1,487 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/caldav"
+	"tg-bot/internal/reminders"
+	"tg-bot/internal/settings"
+)
+
+const (
+	calendarSyncInterval = 5 * time.Minute // как часто синхронизировать календари в фоне
+	calendarSyncTimeout  = time.Minute     // сколько ждать один календарь
+)
+
+// syncResult — что изменилось за одну синхронизацию
+type syncResult struct {
+	pulled  int // новые напоминания из календаря
+	updated int // напоминания, изменённые в календаре
+	removed int // напоминания, удалённые или отменённые в календаре
+	pushed  int // напоминания бота, выгруженные в календарь
+	changed int // события, изменённые в календаре вслед за напоминаниями
+	deleted int // события, удалённые из календаря вслед за напоминаниями
+	skipped int // объекты календаря, которые не удалось разобрать
+
+	kept   []keptChange      // изменения связанных напоминаний, которые не удалось применить
+	linked map[string]string // связанные объекты после синхронизации (см. settings.CalDAV.Linked)
+}
+
+// keptChange — изменение события календаря, которое не применено к напоминанию:
+// напоминание остаётся прежним, а причина попадает в CalDAV.LastErr
+type keptChange struct {
+	text  string // текст напоминания
+	rrule string // неподдерживаемое правило повторения; пусто — событие перенесено в прошлое
+}
+
+// describe описывает пропущенное изменение на языке чата
+func (k keptChange) describe(p chatPrefs) string {
+	if k.rrule != "" {
+		return p.t("caldav.kept_rrule", k.text, k.rrule)
+	}
+	return p.t("caldav.kept_past", k.text)
+}
+
+// describe описывает итог синхронизации на языке чата
+func (r syncResult) describe(p chatPrefs) string {
+	msg := p.t("caldav.result", r.pulled, r.updated, r.removed, r.pushed, r.changed, r.deleted)
+	if r.skipped > 0 {
+		msg += p.t("caldav.result_skipped", r.skipped)
+	}
+	if problem := r.problem(p); problem != "" {
+		msg += "\n⚠️ " + problem
+	}
+	return msg
+}
+
+// problem описывает изменения, которые не удалось применить, для CalDAV.LastErr;
+// пусто — таких нет
+func (r syncResult) problem(p chatPrefs) string {
+	if len(r.kept) == 0 {
+		return ""
+	}
+	lines := make([]string, 0, len(r.kept))
+	for _, k := range r.kept {
+		lines = append(lines, k.describe(p))
+	}
+	return p.t("caldav.kept", strings.Join(lines, "; "))
+}
+
+// TrustCalendarHosts разрешает подключать календари на этих хостах, даже если они
+// во внутренней сети (например, Radicale рядом с ботом). Вызывается до запуска бота.
+func (app *BotApp) TrustCalendarHosts(hosts []string) {
+	app.calendarHosts = hosts
+}
+
+// StartCalendarSync периодически синхронизирует напоминания всех подключённых календарей.
+// Останавливается отменой ctx; Shutdown дожидается текущей синхронизации.
+func (app *BotApp) StartCalendarSync(ctx context.Context) {
+	app.background.Add(1)
+	go func() {
+		defer app.background.Done()
+
+		ticker := time.NewTicker(calendarSyncInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				app.syncAllCalendars(ctx)
+			}
+		}
+	}()
+}
+
+// syncAllCalendars синхронизирует по очереди все чаты с подключённым календарём
+func (app *BotApp) syncAllCalendars(ctx context.Context) {
+	all, err := app.settings.ListAll()
+	if err != nil {
+		log.Printf("Не удалось получить список настроек: %v", err)
+		return
+	}
+
+	for _, s := range all {
+		if s.CalDAV == nil || ctx.Err() != nil {
+			continue
+		}
+		if _, err := app.syncCalendar(ctx, s.ChatID); err != nil {
+			log.Printf("Ошибка синхронизации календаря чата %d: %v", s.ChatID, err)
+		}
+	}
+}
+
+// syncCalendar синхронизирует напоминания чата с его календарём и запоминает результат.
+//
+// Правила разрешения конфликтов:
+//   - для связанных напоминаний источник истины — календарь: изменение или
+//     удаление (отмена) события применяется к напоминанию. Удаляется напоминание
+//     только при явном удалении объекта (сервер отвечает 404) или отмене события;
+//     изменение, которое бот не может применить (событие в прошлом, неподдерживаемое
+//     правило повторения), пропускается, напоминание остаётся, а причина
+//     записывается в CalDAV.LastErr;
+//   - если событие в календаре не менялось, а напоминание изменилось в боте,
+//     событие перезаписывается с If-Match его версии. Перенос повторяющегося
+//     напоминания на следующий раз правило повторения уже описывает, поэтому
+//     выгружается, только если напоминание разошлось с правилом;
+//   - напоминание, удалённое в боте (вручную или после доставки однократного),
+//     удаляет событие с If-Match версии, известной на прошлой синхронизации.
+//     Если событие с тех пор изменили в календаре, оно возвращается новым напоминанием;
+//   - новые напоминания бота выгружаются с If-None-Match: *. Если объект уже есть
+//     (прошлая выгрузка не успела сохранить связь), напоминание связывается с ним,
+//     и его версия подтягивается на следующей синхронизации.
+func (app *BotApp) syncCalendar(ctx context.Context, chatID int64) (syncResult, error) {
+	app.calendarSync.Lock()
+	defer app.calendarSync.Unlock()
+
+	s, err := app.settings.Get(chatID)
+	if err != nil {
+		return syncResult{}, err
+	}
+	if s.CalDAV == nil {
+		return syncResult{}, errors.New("календарь не подключён")
+	}
+
+	loc := s.TimeLocation(app.location)
+	client, err := caldav.NewClient(s.CalDAV.URL, s.CalDAV.Username, s.CalDAV.Password, loc, app.calendarHosts)
+	if err != nil {
+		return syncResult{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, calendarSyncTimeout)
+	defer cancel()
+
+	res, syncErr := app.syncWith(ctx, client, chatID, s.CalDAV.Linked, time.Now().In(loc))
+
+	err = app.updateSettings(chatID, func(s *settings.Settings) {
+		if s.CalDAV == nil {
+			return
+		}
+		if syncErr != nil {
+			s.CalDAV.LastErr = syncErr.Error()
+			return
+		}
+		s.CalDAV.LastSync, s.CalDAV.LastErr = time.Now(), res.problem(app.prefs(chatID))
+		s.CalDAV.Linked = res.linked
+	})
+	if err != nil {
+		log.Printf("Не удалось сохранить состояние синхронизации чата %d: %v", chatID, err)
+	}
+
+	return res, syncErr
+}
+
+// syncWith выполняет двустороннюю синхронизацию: применяет изменения календаря
+// к связанным напоминаниям, создаёт напоминания для новых событий и выгружает
+// в календарь изменения бота. known — связанные объекты на прошлой синхронизации:
+// по ним видно, какие напоминания удалены в боте.
+func (app *BotApp) syncWith(ctx context.Context, client *caldav.Client, chatID int64, known map[string]string, now time.Time) (syncResult, error) {
+	var res syncResult
+
+	objects, skipped, err := client.List(ctx)
+	if err != nil {
+		return res, err
+	}
+	res.skipped = len(skipped)
+
+	remote := make(map[string]caldav.Object, len(objects))
+	for _, obj := range objects {
+		remote[obj.Href] = obj
+	}
+	unreadable := make(map[string]bool, len(skipped))
+	for _, href := range skipped {
+		unreadable[href] = true
+	}
+
+	// 1. Изменения связанных напоминаний в календаре
+	local := app.chatReminders(chatID)
+	linked := make(map[string]bool)
+	for _, r := range local {
+		if r.CalendarHref == "" {
+			continue
+		}
+		linked[r.CalendarHref] = true
+		if unreadable[r.CalendarHref] {
+			continue
+		}
+
+		obj, ok := remote[r.CalendarHref]
+		if ok && obj.ETag == r.CalendarETag {
+			changed, err := app.pushChange(ctx, client, r, obj, now)
+			if err != nil {
+				return res, err
+			}
+			if changed {
+				res.changed++
+			}
+			continue
+		}
+
+		deleted := ok && !obj.Event.Active() // событие отменено или задача выполнена
+		if !ok {
+			exists, err := client.Exists(ctx, r.CalendarHref)
+			if err != nil {
+				return res, err
+			}
+			if exists {
+				// Объект есть, просто не попал в ответ — разберёмся в следующий раз
+				continue
+			}
+			deleted = true
+		}
+		if deleted {
+			if err := app.storage.Delete(r.ID); err != nil && !errors.Is(err, reminders.ErrNotFound) {
+				return res, err
+			}
+			res.removed++
+			continue
+		}
+
+		upd, supported, active := app.calendarReminder(obj, chatID, now)
+		switch {
+		case !supported:
+			res.kept = append(res.kept, keptChange{text: r.Text, rrule: obj.Event.RRule})
+			continue
+		case !active:
+			res.kept = append(res.kept, keptChange{text: r.Text})
+			continue
+		}
+
+		err := app.storage.Update(r.ID, func(cur *reminders.Reminder) {
+			cur.Text, cur.Time = upd.Text, upd.Time
+			cur.Repeat, cur.Offset, cur.Day = upd.Repeat, upd.Offset, upd.Day
+			cur.CalendarETag = obj.ETag
+			cur.State, cur.Attempts, cur.LeaseUntil, cur.LastError = reminders.StatePending, 0, time.Time{}, ""
+		})
+		if err != nil && !errors.Is(err, reminders.ErrNotFound) {
+			return res, err
+		}
+		res.updated++
+	}
+
+	// 2. Новые события календаря и события напоминаний, удалённых в боте
+	for _, obj := range objects {
+		if linked[obj.Href] {
+			continue
+		}
+		if etag, ok := known[obj.Href]; ok && (etag == "" || etag == obj.ETag) {
+			err := client.Delete(ctx, obj.Href, obj.ETag)
+			if err == nil {
+				res.deleted++
+				continue
+			}
+			if !errors.Is(err, caldav.ErrPrecondition) {
+				return res, err
+			}
+			// Событие изменили в календаре после нашего чтения — оно становится новым напоминанием
+		}
+		if !obj.Event.Active() {
+			continue
+		}
+		r, _, active := app.calendarReminder(obj, chatID, now)
+		if !active {
+			continue
+		}
+		if err := app.storage.Add(r); err != nil {
+			return res, err
+		}
+		res.pulled++
+	}
+
+	// 3. Новые напоминания бота
+	for _, r := range local {
+		if r.CalendarHref != "" || r.State == reminders.StateDead {
+			continue
+		}
+
+		href := client.Href(r.ID)
+		etag, err := client.Put(ctx, href, reminderEvent(r), "")
+		if err != nil && !errors.Is(err, caldav.ErrPrecondition) {
+			return res, err
+		}
+
+		err = app.storage.Update(r.ID, func(cur *reminders.Reminder) {
+			cur.CalendarHref, cur.CalendarETag = href, etag
+		})
+		if err != nil && !errors.Is(err, reminders.ErrNotFound) {
+			return res, err
+		}
+		res.pushed++
+	}
+
+	// 4. Связанные объекты для следующей синхронизации; непрочитанные
+	// объекты переносятся как были, чтобы не потерять удаления в боте
+	res.linked = make(map[string]string)
+	for href, etag := range known {
+		if unreadable[href] {
+			res.linked[href] = etag
+		}
+	}
+	for _, r := range app.chatReminders(chatID) {
+		if r.CalendarHref != "" {
+			res.linked[r.CalendarHref] = r.CalendarETag
+		}
+	}
+
+	return res, nil
+}
+
+// pushChange перезаписывает событие obj, если связанное напоминание r изменилось
+// в боте, а событие в календаре с прошлой синхронизации — нет. Напоминание,
+// которое ждёт доставки, не выгружается: его перенос ещё впереди.
+func (app *BotApp) pushChange(ctx context.Context, client *caldav.Client, r reminders.Reminder, obj caldav.Object, now time.Time) (bool, error) {
+	if r.State == reminders.StateDead || !r.Time.After(now) {
+		return false, nil
+	}
+	cur, supported, active := app.calendarReminder(obj, r.ChatID, now)
+	if !supported || !active || sameSchedule(cur, r) {
+		return false, nil
+	}
+
+	etag, err := client.Put(ctx, r.CalendarHref, reminderEvent(r), r.CalendarETag)
+	if errors.Is(err, caldav.ErrPrecondition) {
+		// Событие только что изменили в календаре — его версия придёт со следующей синхронизацией
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	err = app.storage.Update(r.ID, func(cur *reminders.Reminder) { cur.CalendarETag = etag })
+	if err != nil && !errors.Is(err, reminders.ErrNotFound) {
+		return false, err
+	}
+	return true, nil
+}
+
+// sameSchedule сообщает, совпадают ли текст и расписание напоминаний
+func sameSchedule(a, b reminders.Reminder) bool {
+	return a.Text == b.Text && a.Time.Equal(b.Time) && a.Repeat == b.Repeat && a.Offset == b.Offset
+}
+
+// calendarReminder превращает объект календаря в напоминание личного чата.
+// supported = false — правило повторения не поддерживается, и напоминание однократное;
+// active = false — однократное событие уже прошло.
+func (app *BotApp) calendarReminder(obj caldav.Object, chatID int64, now time.Time) (r reminders.Reminder, supported, active bool) {
+	r, supported = eventReminder(obj.Event, app.prefs(chatID).t("ics.untitled"))
+	r.ChatID, r.AuthorID = chatID, chatID
+	r.CalendarHref, r.CalendarETag = obj.Href, obj.ETag
+	r, active = app.upcoming(r, now)
+	return r, supported, active
+}
+
+// unlinkCalendar разрывает связь напоминаний чата с календарём, чтобы при
+// подключении другого календаря они не считались удалёнными из него
+func (app *BotApp) unlinkCalendar(chatID int64) {
+	for _, r := range app.chatReminders(chatID) {
+		if r.CalendarHref == "" {
+			continue
+		}
+		err := app.storage.Update(r.ID, func(cur *reminders.Reminder) {
+			cur.CalendarHref, cur.CalendarETag = "", ""
+		})
+		if err != nil {
+			log.Printf("Не удалось отвязать напоминание %s от календаря: %v", r.ID, err)
+		}
+	}
+}
+
+// handleCalDAV подключает, отключает и синхронизирует календарь:
+// /caldav, /caldav адрес логин пароль, /caldav sync, /caldav off
+func (app *BotApp) handleCalDAV(c tele.Context) error {
+	chatID := c.Chat().ID
+	p := app.prefsFor(c)
+	args := strings.Fields(c.Message().Payload)
+
+	switch {
+	case len(args) == 0:
+		return app.sendCalDAVStatus(c)
+
+	case len(args) == 1 && args[0] == "sync":
+		res, err := app.syncCalendar(context.Background(), chatID)
+		if err != nil {
+			return c.Send(p.t("caldav.sync_failed", err))
+		}
+		return c.Send(p.t("caldav.synced", res.describe(p)))
+
+	case len(args) == 1 && args[0] == "off":
+		err := app.updateSettings(chatID, func(s *settings.Settings) { s.CalDAV = nil })
+		if err != nil {
+			return c.Send(p.t("settings.save_failed"))
+		}
+		app.unlinkCalendar(chatID)
+		return c.Send(p.t("caldav.disconnected"))
+
+	case len(args) == 3:
+		return app.connectCalDAV(c, p, args[0], args[1], args[2])
+	}
+
+	return c.Send(p.t("caldav.usage"))
+}
+
+// connectCalDAV проверяет доступ к календарю, сохраняет подключение и сразу синхронизирует
+func (app *BotApp) connectCalDAV(c tele.Context, p chatPrefs, url, username, password string) error {
+	if c.Chat().Type != tele.ChatPrivate {
+		return c.Send(p.t("caldav.private_only"))
+	}
+	// Сообщение с паролем не должно оставаться в истории
+	if err := c.Delete(); err != nil {
+		log.Printf("Не удалось удалить сообщение с паролем: %v", err)
+	}
+
+	client, err := caldav.NewClient(url, username, password, p.loc, app.calendarHosts)
+	if err != nil {
+		return c.Send(err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), calendarSyncTimeout)
+	defer cancel()
+	if _, _, err := client.List(ctx); err != nil {
+		return c.Send(p.t("caldav.open_failed", err))
+	}
+
+	chatID := c.Chat().ID
+	app.unlinkCalendar(chatID)
+	err = app.updateSettings(chatID, func(s *settings.Settings) {
+		s.CalDAV = &settings.CalDAV{URL: url, Username: username, Password: password}
+	})
+	if err != nil {
+		return c.Send(p.t("settings.save_failed"))
+	}
+
+	res, err := app.syncCalendar(context.Background(), chatID)
+	if err != nil {
+		return c.Send(p.t("caldav.first_sync_failed", err))
+	}
+	return c.Send(p.t("caldav.connected", p.n("plural.minutes_every", int(calendarSyncInterval/time.Minute)), res.describe(p)))
+}
+
+// sendCalDAVStatus показывает состояние подключения
+func (app *BotApp) sendCalDAVStatus(c tele.Context) error {
+	p := app.prefsFor(c)
+	s, err := app.settings.Get(c.Chat().ID)
+	if err != nil {
+		return c.Send(p.t("settings.load_failed"))
+	}
+	if s.CalDAV == nil {
+		return c.Send(p.t("caldav.usage"))
+	}
+
+	msg := p.t("caldav.status", s.CalDAV.URL, s.CalDAV.Username)
+	if s.CalDAV.LastSync.IsZero() {
+		msg += p.t("caldav.never_synced")
+	} else {
+		msg += p.t("caldav.last_sync", p.when(s.CalDAV.LastSync))
+	}
+	if s.CalDAV.LastErr != "" {
+		msg += p.t("caldav.last_error", s.CalDAV.LastErr)
+	}
+	return c.Send(msg + p.t("caldav.status_hint"))
+}
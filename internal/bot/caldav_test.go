@@ -0,0 +1,409 @@
+package bot
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"tg-bot/internal/caldav"
+	"tg-bot/internal/ical"
+	"tg-bot/internal/reminders"
+	"tg-bot/internal/settings"
+)
+
+// Синхронизация проверяется на встроенном фейковом сервере CalDAV. Чтобы прогнать
+// те же сценарии на настоящем Radicale, поднимите его (internal/caldav/testdata/compose.yaml)
+// и укажите адрес пользователя:
+//
+//	docker compose -f internal/caldav/testdata/compose.yaml up -d
+//	CALDAV_TEST_URL=http://localhost:5232/test/ go test ./internal/bot -run Calendar
+//
+// Логин и пароль — CALDAV_TEST_USER и CALDAV_TEST_PASSWORD (по умолчанию test/test).
+
+const testChatID = 1
+
+// fakeCalDAV — минимальный сервер CalDAV в памяти: REPORT, GET, PUT и DELETE
+type fakeCalDAV struct {
+	mu      sync.Mutex
+	objects map[string]string // путь → iCalendar
+	etags   map[string]int
+	version int
+	hidden  map[string]bool // объекты, которые не попадают в ответ на REPORT
+}
+
+func newFakeCalDAV() *fakeCalDAV {
+	return &fakeCalDAV{objects: make(map[string]string), etags: make(map[string]int), hidden: make(map[string]bool)}
+}
+
+func (f *fakeCalDAV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := r.URL.Path
+	switch r.Method {
+	case "REPORT":
+		var b strings.Builder
+		b.WriteString(`<?xml version="1.0" encoding="utf-8"?><d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">`)
+		for href, data := range f.objects {
+			if f.hidden[href] {
+				continue
+			}
+			fmt.Fprintf(&b, `<d:response><d:href>%s</d:href><d:propstat><d:prop><d:getetag>"%d"</d:getetag><c:calendar-data>`, href, f.etags[href])
+			xml.EscapeText(&b, []byte(data))
+			b.WriteString(`</c:calendar-data></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`)
+		}
+		b.WriteString(`</d:multistatus>`)
+		w.WriteHeader(http.StatusMultiStatus)
+		io.WriteString(w, b.String())
+
+	case http.MethodGet:
+		data, ok := f.objects[path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, data)
+
+	case http.MethodPut:
+		_, exists := f.objects[path]
+		if r.Header.Get("If-None-Match") == "*" && exists {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		if m := r.Header.Get("If-Match"); m != "" && m != fmt.Sprintf(`"%d"`, f.etags[path]) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		f.version++
+		f.objects[path], f.etags[path] = string(body), f.version
+		w.Header().Set("ETag", fmt.Sprintf(`"%d"`, f.version))
+		w.WriteHeader(http.StatusCreated)
+
+	case http.MethodDelete:
+		if _, ok := f.objects[path]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if m := r.Header.Get("If-Match"); m != "" && m != fmt.Sprintf(`"%d"`, f.etags[path]) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		delete(f.objects, path)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// testCalendar возвращает адрес пустого календаря, логин и пароль:
+// новый календарь на Radicale, если задан CALDAV_TEST_URL, иначе фейковый сервер
+func testCalendar(t *testing.T) (calendarURL, user, password string, fake *fakeCalDAV) {
+	t.Helper()
+
+	base := os.Getenv("CALDAV_TEST_URL")
+	if base == "" {
+		fake = newFakeCalDAV()
+		srv := httptest.NewServer(fake)
+		t.Cleanup(srv.Close)
+		return srv.URL + "/test/calendar/", "test", "test", fake
+	}
+
+	user, password = os.Getenv("CALDAV_TEST_USER"), os.Getenv("CALDAV_TEST_PASSWORD")
+	if user == "" {
+		user, password = "test", "test"
+	}
+	calendarURL = strings.TrimSuffix(base, "/") + fmt.Sprintf("/tg-bot-test-%d/", time.Now().UnixNano())
+
+	req, _ := http.NewRequest("MKCALENDAR", calendarURL, nil)
+	req.SetBasicAuth(user, password)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Radicale недоступен: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		t.Fatalf("не удалось создать календарь %s: %s", calendarURL, res.Status)
+	}
+	t.Cleanup(func() {
+		req, _ := http.NewRequest(http.MethodDelete, calendarURL, nil)
+		req.SetBasicAuth(user, password)
+		if res, err := http.DefaultClient.Do(req); err == nil {
+			res.Body.Close()
+		}
+	})
+	return calendarURL, user, password, nil
+}
+
+// calendarTest — бот с хранилищами в памяти и клиент к тестовому календарю
+type calendarTest struct {
+	t      *testing.T
+	app    *BotApp
+	client *caldav.Client
+	url    string
+	user   string
+	pass   string
+	fake   *fakeCalDAV
+	now    time.Time
+	known  map[string]string // связанные объекты после прошлой синхронизации
+}
+
+func newCalendarTest(t *testing.T) *calendarTest {
+	calendarURL, user, pass, fake := testCalendar(t)
+	u, err := url.Parse(calendarURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Тестовые серверы работают на localhost
+	client, err := caldav.NewClient(calendarURL, user, pass, time.UTC, []string{u.Hostname()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := &BotApp{
+		location: time.UTC,
+		storage:  reminders.NewMemoryStorage(),
+		settings: settings.NewMemoryStorage(),
+	}
+	return &calendarTest{t: t, app: app, client: client, url: calendarURL, user: user, pass: pass, fake: fake,
+		now: time.Now().UTC().Truncate(time.Second)}
+}
+
+func (ct *calendarTest) sync() syncResult {
+	ct.t.Helper()
+	res, err := ct.app.syncWith(context.Background(), ct.client, testChatID, ct.known, ct.now)
+	if err != nil {
+		ct.t.Fatalf("синхронизация: %v", err)
+	}
+	ct.known = res.linked
+	return res
+}
+
+// reminder возвращает напоминание чата, связанное с объектом href
+func (ct *calendarTest) reminder(href string) (reminders.Reminder, bool) {
+	for _, r := range ct.app.chatReminders(testChatID) {
+		if r.CalendarHref == href {
+			return r, true
+		}
+	}
+	return reminders.Reminder{}, false
+}
+
+// object возвращает объект календаря href
+func (ct *calendarTest) object(href string) (caldav.Object, bool) {
+	ct.t.Helper()
+	objects, _, err := ct.client.List(context.Background())
+	if err != nil {
+		ct.t.Fatal(err)
+	}
+	for _, obj := range objects {
+		if obj.Href == href {
+			return obj, true
+		}
+	}
+	return caldav.Object{}, false
+}
+
+// put создаёт или перезаписывает событие в календаре в обход бота
+func (ct *calendarTest) put(href string, e ical.Event) {
+	ct.t.Helper()
+	objects, _, err := ct.client.List(context.Background())
+	if err != nil {
+		ct.t.Fatal(err)
+	}
+	etag := ""
+	for _, obj := range objects {
+		if obj.Href == href {
+			etag = obj.ETag
+		}
+	}
+	if _, err := ct.client.Put(context.Background(), href, e, etag); err != nil {
+		ct.t.Fatalf("запись %s: %v", href, err)
+	}
+}
+
+// remove удаляет объект из календаря в обход бота
+func (ct *calendarTest) remove(href string) {
+	ct.t.Helper()
+	u, err := url.Parse(ct.url)
+	if err != nil {
+		ct.t.Fatal(err)
+	}
+	u.Path = href
+	req, _ := http.NewRequest(http.MethodDelete, u.String(), nil)
+	req.SetBasicAuth(ct.user, ct.pass)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		ct.t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode >= 300 {
+		ct.t.Fatalf("удаление %s: %s", href, res.Status)
+	}
+}
+
+func TestCalendarSync(t *testing.T) {
+	ct := newCalendarTest(t)
+
+	// 1. Напоминание бота выгружается в календарь
+	local := reminders.Reminder{ChatID: testChatID, AuthorID: testChatID, Text: "Полить цветы", Time: ct.now.Add(time.Hour), Repeat: reminders.RepeatDaily}
+	if err := ct.app.storage.Add(local); err != nil {
+		t.Fatal(err)
+	}
+	if res := ct.sync(); res.pushed != 1 {
+		t.Fatalf("выгружено %d напоминаний, ожидалось 1", res.pushed)
+	}
+	ours := ct.app.chatReminders(testChatID)[0]
+	if ours.CalendarHref == "" {
+		t.Fatal("напоминание не связано с календарём")
+	}
+
+	// 2. Новое событие календаря становится напоминанием
+	theirs := ct.client.Href("meeting")
+	ct.put(theirs, ical.Event{UID: "meeting", Summary: "Встреча", Start: ct.now.Add(2 * time.Hour)})
+	if res := ct.sync(); res.pulled != 1 {
+		t.Fatalf("получено %d событий, ожидалось 1", res.pulled)
+	}
+
+	// 3. Неподдерживаемое правило повторения не удаляет напоминание
+	ct.put(ours.CalendarHref, ical.Event{UID: ours.ID + "@tg-bot", Summary: "Полить цветы", Start: ours.Time, RRule: "FREQ=DAILY;INTERVAL=2"})
+	res := ct.sync()
+	if res.removed != 0 || len(res.kept) != 1 {
+		t.Fatalf("удалено %d, пропущено %d изменений; ожидалось 0 и 1", res.removed, len(res.kept))
+	}
+	if r, ok := ct.reminder(ours.CalendarHref); !ok || r.Repeat != reminders.RepeatDaily {
+		t.Fatalf("напоминание с неподдерживаемым правилом изменено или удалено: %+v", r)
+	}
+	if res.problem(ct.app.prefs(testChatID)) == "" {
+		t.Error("пропущенное изменение не попадает в LastErr")
+	}
+
+	// 4. Перенос однократного события в прошлое тоже не удаляет напоминание
+	ct.put(theirs, ical.Event{UID: "meeting", Summary: "Встреча", Start: ct.now.Add(-time.Hour)})
+	if res := ct.sync(); res.removed != 0 {
+		t.Fatalf("удалено %d напоминаний после переноса в прошлое", res.removed)
+	}
+	if _, ok := ct.reminder(theirs); !ok {
+		t.Fatal("напоминание удалено после переноса события в прошлое")
+	}
+
+	// 5. Удаление объекта на сервере удаляет напоминание
+	ct.remove(theirs)
+	if res := ct.sync(); res.removed != 1 {
+		t.Fatalf("удалено %d напоминаний, ожидалось 1", res.removed)
+	}
+	if _, ok := ct.reminder(theirs); ok {
+		t.Fatal("напоминание удалённого события осталось")
+	}
+
+	// 6. Отмена события удаляет напоминание
+	ct.put(ours.CalendarHref, ical.Event{UID: ours.ID + "@tg-bot", Summary: "Полить цветы", Start: ours.Time, Status: "CANCELLED"})
+	if res := ct.sync(); res.removed != 1 {
+		t.Fatalf("удалено %d напоминаний после отмены, ожидалось 1", res.removed)
+	}
+	if _, ok := ct.reminder(ours.CalendarHref); ok {
+		t.Fatal("напоминание отменённого события осталось")
+	}
+}
+
+func TestCalendarSyncKeepsRemindersMissingFromListing(t *testing.T) {
+	ct := newCalendarTest(t)
+	if ct.fake == nil {
+		t.Skip("неполный ответ REPORT можно смоделировать только на фейковом сервере")
+	}
+
+	href := ct.client.Href("meeting")
+	ct.put(href, ical.Event{UID: "meeting", Summary: "Встреча", Start: ct.now.Add(time.Hour)})
+	ct.sync()
+
+	// Объект есть на сервере, но не попал в ответ и к тому же изменился
+	ct.put(href, ical.Event{UID: "meeting", Summary: "Встреча", Start: ct.now.Add(2 * time.Hour)})
+	ct.fake.mu.Lock()
+	ct.fake.hidden[href] = true
+	ct.fake.mu.Unlock()
+
+	if res := ct.sync(); res.removed != 0 {
+		t.Fatalf("удалено %d напоминаний из-за неполного ответа", res.removed)
+	}
+	if _, ok := ct.reminder(href); !ok {
+		t.Fatal("напоминание удалено из-за неполного ответа")
+	}
+}
+
+func TestCalendarSyncPushesBotChanges(t *testing.T) {
+	ct := newCalendarTest(t)
+
+	daily := reminders.Reminder{ChatID: testChatID, AuthorID: testChatID, Text: "Полить цветы", Time: ct.now.Add(time.Hour), Repeat: reminders.RepeatDaily}
+	once := reminders.Reminder{ChatID: testChatID, AuthorID: testChatID, Text: "Позвонить", Time: ct.now.Add(2 * time.Hour)}
+	for _, r := range []reminders.Reminder{daily, once} {
+		if err := ct.app.storage.Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ct.sync()
+	list := ct.app.chatReminders(testChatID)
+	daily, once = list[0], list[1]
+
+	// 1. Перенос повторяющегося по его правилу после доставки событие не меняет
+	next := daily.Time.Add(24 * time.Hour)
+	ct.now = daily.Time.Add(time.Minute)
+	if err := ct.app.storage.Reschedule(daily.ID, next); err != nil {
+		t.Fatal(err)
+	}
+	if res := ct.sync(); res.changed != 0 {
+		t.Fatalf("изменено %d событий после переноса по правилу, ожидалось 0", res.changed)
+	}
+
+	// 2. Напоминание, разошедшееся с событием, перезаписывает его
+	moved := ct.now.Add(3 * time.Hour)
+	err := ct.app.storage.Update(once.ID, func(r *reminders.Reminder) { r.Text, r.Time = "Перезвонить", moved })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := ct.sync(); res.changed != 1 || res.updated != 0 {
+		t.Fatalf("в календаре изменено %d, из календаря %d; ожидалось 1 и 0", res.changed, res.updated)
+	}
+	obj, ok := ct.object(once.CalendarHref)
+	if !ok || obj.Event.Summary != "Перезвонить" || !obj.Event.Start.Equal(moved) {
+		t.Fatalf("событие не перезаписано: %+v", obj.Event)
+	}
+	if r, _ := ct.reminder(once.CalendarHref); r.CalendarETag != obj.ETag {
+		t.Errorf("версия события %s не сохранена в напоминании (%s)", obj.ETag, r.CalendarETag)
+	}
+	if res := ct.sync(); res.changed != 0 || res.updated != 0 {
+		t.Fatalf("повторная синхронизация изменила %d и %d событий", res.changed, res.updated)
+	}
+
+	// 3. Доставленное однократное напоминание удаляет событие
+	if err := ct.app.storage.Ack(once.ID); err != nil {
+		t.Fatal(err)
+	}
+	if res := ct.sync(); res.deleted != 1 || res.pulled != 0 {
+		t.Fatalf("удалено %d событий, получено %d; ожидалось 1 и 0", res.deleted, res.pulled)
+	}
+	if _, ok := ct.object(once.CalendarHref); ok {
+		t.Fatal("событие доставленного напоминания осталось в календаре")
+	}
+
+	// 4. Событие, изменённое в календаре после удаления напоминания, не удаляется,
+	// а возвращается напоминанием
+	if err := ct.app.storage.Delete(daily.ID); err != nil {
+		t.Fatal(err)
+	}
+	ct.put(daily.CalendarHref, ical.Event{UID: daily.ID + "@tg-bot", Summary: "Полить цветы и кактус", Start: next, RRule: "FREQ=DAILY"})
+	if res := ct.sync(); res.deleted != 0 || res.pulled != 1 {
+		t.Fatalf("удалено %d событий, получено %d; ожидалось 0 и 1", res.deleted, res.pulled)
+	}
+	if r, ok := ct.reminder(daily.CalendarHref); !ok || r.Text != "Полить цветы и кактус" {
+		t.Fatalf("изменённое событие не вернулось напоминанием: %+v", r)
+	}
+}
@@ -0,0 +1,169 @@
+package bot
+
+import (
+	"cmp"
+	"log"
+	"strings"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/i18n"
+)
+
+// commandScope — в каких чатах доступна команда
+type commandScope int
+
+const (
+	scopeAll     commandScope = iota // в личных чатах и в группах
+	scopePrivate                     // только в личном чате с ботом
+	scopeGroup                       // только в группах
+)
+
+// allows сообщает, доступна ли команда в чате указанного типа
+func (s commandScope) allows(private bool) bool {
+	switch s {
+	case scopePrivate:
+		return private
+	case scopeGroup:
+		return !private
+	default:
+		return true
+	}
+}
+
+// command — команда бота. Из этих определений регистрируются обработчики,
+// строится /help и список команд в Telegram (setMyCommands).
+type command struct {
+	name    string // без «/»
+	args    string // ключ описания аргументов: «[clear]»; пусто — команда без аргументов
+	desc    string // ключ короткого описания для меню команд Telegram
+	help    string // ключ подробного описания для /help
+	scope   commandScope
+	hidden  bool // синоним другой команды: не показывается в /help и меню команд
+	admin   bool // меняет настройки чата: в группах доступна только администраторам
+	handler tele.HandlerFunc
+}
+
+// commands описывает все команды бота в порядке показа в /help и меню Telegram
+func (app *BotApp) commands() []command {
+	return []command{
+		{name: "start", desc: "cmd.start", help: "cmd.start.help", handler: app.handleStart},
+		{name: "hello", hidden: true, handler: app.handleStart},
+		{name: "help", desc: "cmd.help", help: "cmd.help.help", handler: app.handleHelp},
+		{name: "remind", args: "cmd.remind.args", desc: "cmd.remind", help: "cmd.remind.help", handler: app.handleRemind},
+		{name: "reminders", args: "cmd.reminders.args", desc: "cmd.reminders", help: "cmd.reminders.help", handler: app.handleReminders},
+		{name: "remind_sunset", args: "cmd.remind_sunset.args", desc: "cmd.remind_sunset", help: "cmd.remind_sunset.help", handler: app.handleRemindSunset},
+		{name: "remind_at", args: "cmd.remind_at.args", desc: "cmd.remind_at", help: "cmd.remind_at.help", handler: app.handleRemindAt},
+		{name: "export", args: "cmd.export.args", desc: "cmd.export", help: "cmd.export.help", handler: app.handleExport},
+		{name: "caldav", args: "cmd.caldav.args", desc: "cmd.caldav", help: "cmd.caldav.help", scope: scopePrivate, handler: app.handleCalDAV},
+		{name: "expense", desc: "cmd.expense", help: "cmd.expense.help", handler: app.handleExpense},
+		{name: "expenses", desc: "cmd.expenses", help: "cmd.expenses.help", handler: app.handleExpenses},
+		{name: "cancel", desc: "cmd.cancel", help: "cmd.cancel.help", handler: app.handleCancel},
+		{name: "settings", desc: "cmd.settings", help: "cmd.settings.help", admin: true, handler: app.handleSettings},
+		{name: "subscribe", desc: "cmd.subscribe", help: "cmd.subscribe.help", admin: true, handler: app.handleSubscribe},
+		{name: "unsubscribe", desc: "cmd.unsubscribe", help: "cmd.unsubscribe.help", admin: true, handler: app.handleUnsubscribe},
+		{name: "brief_air", args: "cmd.brief_air.args", desc: "cmd.brief_air", help: "cmd.brief_air.help", admin: true, handler: app.handleBriefAir},
+		{name: "air_alert", args: "cmd.air_alert.args", desc: "cmd.air_alert", help: "cmd.air_alert.help", admin: true, handler: app.handleAirAlert},
+		{name: "advice", args: "cmd.advice.args", desc: "cmd.advice", help: "cmd.advice.help", admin: true, handler: app.handleAdvice},
+	}
+}
+
+// registerCommands регистрирует обработчики всех команд из определений
+func (app *BotApp) registerCommands() {
+	for _, cmd := range app.commands() {
+		if cmd.admin {
+			app.bot.Handle("/"+cmd.name, app.adminOnly(cmd.handler))
+			continue
+		}
+		app.bot.Handle("/"+cmd.name, cmd.handler)
+	}
+}
+
+// helpText строит /help из определений команд: только команды, доступные в этом чате
+func (app *BotApp) helpText(p chatPrefs, private bool) string {
+	var b strings.Builder
+	for _, cmd := range app.commands() {
+		if cmd.hidden || !cmd.scope.allows(private) {
+			continue
+		}
+
+		b.WriteString("/" + cmd.name)
+		if cmd.args != "" {
+			b.WriteString(" " + p.t(cmd.args))
+		}
+		b.WriteString("\n")
+
+		// Первая строка описания начинается с тире, продолжения выравниваются под текст
+		for i, line := range strings.Split(p.t(cmd.help), "\n") {
+			switch {
+			case i == 0:
+				b.WriteString("    — " + line + "\n")
+			case strings.HasPrefix(line, "— "):
+				b.WriteString("    " + line + "\n")
+			default:
+				b.WriteString("      " + line + "\n")
+			}
+		}
+		if cmd.admin && !private {
+			b.WriteString("      " + p.t("help.admins_only") + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(p.t("help.footer", app.bot.Me.Username))
+	return b.String()
+}
+
+// telegramCommands возвращает список команд для меню Telegram на языке lang.
+// Команды настроек группы (admin) попадают в список, только если admins.
+func (app *BotApp) telegramCommands(lang string, private, admins bool) []tele.Command {
+	var list []tele.Command
+	for _, cmd := range app.commands() {
+		if cmd.hidden || !cmd.scope.allows(private) || (cmd.admin && !private && !admins) {
+			continue
+		}
+		list = append(list, tele.Command{Text: cmd.name, Description: i18n.T(lang, cmd.desc)})
+	}
+	return list
+}
+
+// PublishCommands отправляет меню команд в Telegram отдельно для личных чатов, групп
+// и администраторов групп и для каждого языка. Список без языка Telegram показывает пользователям остальных языков.
+// Это десяток запросов setMyCommands, поэтому вызывается только при запуске
+// долгоживущего процесса (cmd/bot) или при деплое serverless-версии (bot -mode=setup),
+// а не на каждом холодном старте функции.
+// Ошибки только логируются: без меню команд бот всё равно работает.
+func (app *BotApp) PublishCommands() {
+	scopes := []struct {
+		scope   tele.CommandScope
+		private bool
+		admins  bool
+	}{
+		{tele.CommandScope{Type: tele.CommandScopeAllPrivateChats}, true, true},
+		{tele.CommandScope{Type: tele.CommandScopeAllGroupChats}, false, false},
+		{tele.CommandScope{Type: tele.CommandScopeAllChatAdmin}, false, true},
+	}
+
+	for _, s := range scopes {
+		for _, lang := range append([]string{""}, i18n.Languages...) {
+			list := app.telegramCommands(cmp.Or(lang, i18n.Default), s.private, s.admins)
+			if err := app.bot.SetCommands(list, s.scope, lang); err != nil {
+				log.Printf("Не удалось опубликовать команды (%s, %q): %v", s.scope.Type, lang, err)
+			}
+		}
+	}
+}
+
+// handleStart приветствует пользователя и показывает главное меню
+func (app *BotApp) handleStart(c tele.Context) error {
+	p := app.prefsFor(c)
+	inline := &tele.ReplyMarkup{}
+	inline.Inline(inline.Row(inline.Data(p.t("greet.settings"), settingsBtn.Unique)))
+	c.Send(p.t("greet"), inline)
+	return app.showMenu(mainMenu)(c)
+}
+
+// handleHelp показывает команды, доступные в этом чате
+func (app *BotApp) handleHelp(c tele.Context) error {
+	return c.Send(app.helpText(app.prefsFor(c), c.Chat().Type == tele.ChatPrivate))
+}
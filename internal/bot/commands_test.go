@@ -0,0 +1,145 @@
+package bot
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/i18n"
+	"tg-bot/internal/settings"
+)
+
+func TestCommandTexts(t *testing.T) {
+	app, _ := newTestBot(t)
+
+	for _, cmd := range app.commands() {
+		if cmd.handler == nil {
+			t.Errorf("/%s без обработчика", cmd.name)
+		}
+		if cmd.hidden {
+			continue
+		}
+		for _, key := range []string{cmd.desc, cmd.help, cmd.args} {
+			if key == "" {
+				continue
+			}
+			for _, lang := range i18n.Languages {
+				if i18n.T(lang, key) == key {
+					t.Errorf("/%s: нет сообщения %s (%s)", cmd.name, key, lang)
+				}
+			}
+		}
+	}
+}
+
+func TestHelpText(t *testing.T) {
+	app, _ := newTestBot(t)
+	p := prefsOf(settings.Settings{}, testZone)
+
+	private := app.helpText(p, true)
+	group := app.helpText(p, false)
+
+	for _, tt := range []struct {
+		name string
+		text string
+		has  []string
+		not  []string
+	}{
+		{"личный чат", private, []string{"/remind", "/caldav", "/settings"}, []string{"/hello", p.t("help.admins_only")}},
+		{"группа", group, []string{"/remind", "/settings", p.t("help.admins_only")}, []string{"/hello", "/caldav"}},
+	} {
+		for _, s := range tt.has {
+			if !strings.Contains(tt.text, s) {
+				t.Errorf("%s: в /help нет %q", tt.name, s)
+			}
+		}
+		for _, s := range tt.not {
+			if strings.Contains(tt.text, s) {
+				t.Errorf("%s: в /help есть %q", tt.name, s)
+			}
+		}
+		if !strings.HasSuffix(tt.text, p.t("help.footer", "test_bot")) {
+			t.Errorf("%s: /help без подвала", tt.name)
+		}
+	}
+}
+
+func TestHelpCommand(t *testing.T) {
+	app, tg := newTestBot(t)
+
+	send(app, testGroup, testUser, "/help@test_bot")
+	if got, want := lastText(t, tg), app.helpText(prefsOf(settings.Settings{}, testZone), false); got != want {
+		t.Errorf("/help в группе:\n%s\nожидалось:\n%s", got, want)
+	}
+}
+
+func TestTelegramCommands(t *testing.T) {
+	app, _ := newTestBot(t)
+
+	tests := []struct {
+		name    string
+		private bool
+		admins  bool
+		has     []string
+		not     []string
+	}{
+		{"личный чат", true, true, []string{"start", "caldav", "settings"}, []string{"hello"}},
+		{"группа", false, false, []string{"start", "remind"}, []string{"hello", "caldav", "settings", "advice"}},
+		{"администраторы группы", false, true, []string{"start", "settings", "advice"}, []string{"hello", "caldav"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, cmd := range app.telegramCommands("en", tt.private, tt.admins) {
+				if cmd.Description == "" || cmd.Description == i18n.T("ru", "cmd."+cmd.Text) {
+					t.Errorf("/%s: описание %q не на английском", cmd.Text, cmd.Description)
+				}
+				names = append(names, cmd.Text)
+			}
+			for _, name := range tt.has {
+				if !slices.Contains(names, name) {
+					t.Errorf("в меню нет /%s: %q", name, names)
+				}
+			}
+			for _, name := range tt.not {
+				if slices.Contains(names, name) {
+					t.Errorf("в меню есть /%s", name)
+				}
+			}
+		})
+	}
+}
+
+func TestPublishCommands(t *testing.T) {
+	app, tg := newTestBot(t)
+
+	app.PublishCommands()
+
+	calls := tg.sent("setMyCommands")
+	if want := 3 * (len(i18n.Languages) + 1); len(calls) != want {
+		t.Fatalf("отправлено %d списков команд, ожидалось %d", len(calls), want)
+	}
+	groups := 0
+	for _, c := range calls {
+		scope, _ := c.Params["scope"].(map[string]any)
+		if scope["type"] != string(tele.CommandScopeAllGroupChats) {
+			continue
+		}
+		groups++
+		commands, _ := c.Params["commands"].([]any)
+		if len(commands) == 0 {
+			t.Errorf("пустое меню групп (%v)", c.Params["language_code"])
+		}
+		for _, cmd := range commands {
+			if name := cmd.(map[string]any)["command"]; name == "settings" {
+				t.Errorf("в меню групп (%v) есть /settings", c.Params["language_code"])
+			}
+		}
+	}
+	if groups != len(i18n.Languages)+1 {
+		t.Errorf("меню групп отправлено %d раз", groups)
+	}
+}
@@ -0,0 +1,272 @@
+package bot
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/conversation"
+)
+
+// conversationTimeout — сколько ждать ответа на шаге, прежде чем бросить диалог
+const conversationTimeout = 10 * time.Minute
+
+// step — шаг сценария: вопрос пользователю и разбор его ответа.
+// answer возвращает следующий шаг: "" — сценарий завершён, тот же шаг — ответ
+// не подошёл (answer уже объяснил почему), и вопрос повторно не задаётся.
+type step struct {
+	ask    func(c tele.Context, st *conversation.State) error
+	answer func(c tele.Context, st *conversation.State, text string) (string, error)
+}
+
+// flow — сценарий: первый шаг и все шаги по именам
+type flow struct {
+	start string
+	steps map[string]step
+}
+
+// conversationFlows собирает все многошаговые сценарии бота
+func (app *BotApp) conversationFlows() map[string]flow {
+	return map[string]flow{
+		"remind":  app.remindFlow(),
+		"expense": app.expenseFlow(),
+	}
+}
+
+// dialogKey — участник чата, который ведёт диалог
+type dialogKey struct {
+	chatID, userID int64
+}
+
+// dialogIndex помнит, у кого есть диалоги, начатые или продолженные этим процессом,
+// чтобы обычные сообщения не читали хранилище диалогов. При long polling апдейты
+// обрабатывает один процесс, и после complete индекс знает все диалоги: начатые до
+// запуска к этому моменту истекли. С вебхуком экземпляров может быть несколько,
+// поэтому без записи в индексе диалог ищется в хранилище.
+type dialogIndex struct {
+	mu       sync.Mutex
+	expires  map[dialogKey]time.Time
+	complete time.Time // с этого момента индекс знает все диалоги; нулевое — никогда
+}
+
+// trustAfter объявляет индекс полным, начиная с момента t
+func (d *dialogIndex) trustAfter(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.complete = t
+}
+
+// track запоминает диалог участника до его истечения
+func (d *dialogIndex) track(st conversation.State) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.expires == nil {
+		d.expires = make(map[dialogKey]time.Time)
+	}
+	d.expires[dialogKey{st.ChatID, st.UserID}] = st.ExpiresAt
+}
+
+// forget забывает завершённый диалог
+func (d *dialogIndex) forget(chatID, userID int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.expires, dialogKey{chatID, userID})
+}
+
+// mayHave сообщает, может ли у участника быть диалог; false — точно нет,
+// и хранилище читать не нужно
+func (d *dialogIndex) mayHave(chatID, userID int64, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	k := dialogKey{chatID, userID}
+	if until, ok := d.expires[k]; ok {
+		if now.Before(until) {
+			return true
+		}
+		delete(d.expires, k)
+	}
+	return d.complete.IsZero() || now.Before(d.complete)
+}
+
+// dialog возвращает активный диалог отправителя в текущем чате; хранилище
+// читается, только если индекс не знает наверняка, что диалога нет
+func (app *BotApp) dialog(c tele.Context) (conversation.State, bool, error) {
+	chatID, userID := c.Chat().ID, c.Sender().ID
+	if !app.dialogs.mayHave(chatID, userID, time.Now()) {
+		return conversation.State{}, false, nil
+	}
+
+	st, ok, err := app.conversations.Get(chatID, userID)
+	if err != nil {
+		return conversation.State{}, false, err
+	}
+	if ok {
+		app.dialogs.track(st)
+	}
+	return st, ok, nil
+}
+
+// saveDialog сохраняет диалог в хранилище и в индексе
+func (app *BotApp) saveDialog(st conversation.State) error {
+	if err := app.conversations.Save(st); err != nil {
+		return err
+	}
+	app.dialogs.track(st)
+	return nil
+}
+
+// startFlow начинает сценарий для отправителя, заменяя его незавершённый
+func (app *BotApp) startFlow(c tele.Context, name string) error {
+	f, ok := app.flows[name]
+	if !ok {
+		log.Printf("Неизвестный сценарий %q", name)
+		return nil
+	}
+
+	st := conversation.State{
+		ChatID:    c.Chat().ID,
+		UserID:    c.Sender().ID,
+		Flow:      name,
+		Step:      f.start,
+		Data:      make(map[string]string),
+		ExpiresAt: time.Now().Add(conversationTimeout),
+	}
+	if c.Callback() == nil {
+		st.MessageID = c.Message().ID
+	}
+	if err := app.saveDialog(st); err != nil {
+		log.Printf("Не удалось сохранить диалог %d в чате %d: %v", st.UserID, st.ChatID, err)
+		return c.Send(app.prefsFor(c).t("flow.start_failed"))
+	}
+
+	return f.steps[f.start].ask(c, &st)
+}
+
+// handleText передаёт текст текущему шагу диалога отправителя. Сообщения вне
+// диалога и команды не перехватываются; на упоминание бота в группе вне диалога
+// отвечает главное меню.
+func (app *BotApp) handleText(c tele.Context) error {
+	text := strings.TrimSpace(c.Text())
+	if strings.HasPrefix(text, "/") {
+		return nil
+	}
+
+	st, ok, err := app.dialog(c)
+	if err != nil {
+		log.Printf("Не удалось получить диалог %d в чате %d: %v", c.Sender().ID, c.Chat().ID, err)
+		return nil
+	}
+	if !ok {
+		if m := c.Message(); m.FromGroup() && app.mentionsBot(m) {
+			return app.showMenu(mainMenu)(c)
+		}
+		return nil
+	}
+
+	st.MessageID = c.Message().ID
+	return app.advanceFlow(c, st, text)
+}
+
+// advanceFlow передаёт ответ текущему шагу диалога и переходит к следующему.
+// Ответ приходит текстом или из инлайн-виджета (см. picker.go).
+func (app *BotApp) advanceFlow(c tele.Context, st conversation.State, answer string) error {
+	s, ok := app.flows[st.Flow].steps[st.Step]
+	if !ok {
+		// Сценарий изменился после обновления бота — начинать заново
+		return app.finishFlow(st.ChatID, st.UserID)
+	}
+
+	next, err := s.answer(c, &st, answer)
+	if err != nil {
+		return err
+	}
+	if next == "" {
+		return app.finishFlow(st.ChatID, st.UserID)
+	}
+
+	moved := next != st.Step
+	st.Step = next
+	st.ExpiresAt = time.Now().Add(conversationTimeout)
+	if err := app.saveDialog(st); err != nil {
+		log.Printf("Не удалось сохранить диалог %d в чате %d: %v", st.UserID, st.ChatID, err)
+		return c.Send(app.prefsFor(c).t("flow.save_failed"))
+	}
+
+	if !moved {
+		return nil
+	}
+	return app.flows[st.Flow].steps[next].ask(c, &st)
+}
+
+// finishFlow завершает диалог участника чата
+func (app *BotApp) finishFlow(chatID, userID int64) error {
+	app.dialogs.forget(chatID, userID)
+	if err := app.conversations.Delete(chatID, userID); err != nil {
+		log.Printf("Не удалось завершить диалог %d в чате %d: %v", userID, chatID, err)
+	}
+	return nil
+}
+
+// handleCancel отменяет текущий диалог отправителя: /cancel
+func (app *BotApp) handleCancel(c tele.Context) error {
+	p := app.prefsFor(c)
+	if _, ok, err := app.dialog(c); err != nil || !ok {
+		return c.Send(p.t("flow.nothing_to_cancel"), app.menuMarkup(c, p, mainMenu))
+	}
+
+	app.finishFlow(c.Chat().ID, c.Sender().ID)
+	return c.Send(p.t("flow.cancelled"), app.menuMarkup(c, p, mainMenu))
+}
+
+// prompt задаёт вопрос шага диалога (или объясняет, почему ответ не подошёл).
+// В группе с режимом приватности Telegram доставляет боту только ответы на его
+// сообщения, поэтому там вопрос отправляется ответом на сообщение участника,
+// который ведёт диалог, с ForceReply, а клавиатура с вариантами — Selective,
+// чтобы её видел только он. Инлайн-виджеты отвечают колбеками, им это не нужно.
+func (app *BotApp) prompt(c tele.Context, st *conversation.State, text string, markup *tele.ReplyMarkup) error {
+	if c.Chat().Type == tele.ChatPrivate {
+		if markup == nil {
+			return c.Send(text)
+		}
+		return c.Send(text, markup)
+	}
+
+	switch {
+	case markup == nil || markup.RemoveKeyboard:
+		markup = &tele.ReplyMarkup{ForceReply: true, Selective: true}
+	case markup.InlineKeyboard == nil:
+		selective := *markup
+		selective.Selective = true
+		markup = &selective
+	}
+
+	opts := &tele.SendOptions{ReplyMarkup: markup}
+	if st.MessageID != 0 {
+		opts.ReplyTo = &tele.Message{ID: st.MessageID, Chat: c.Chat()}
+	}
+	return c.Send(text, opts)
+}
+
+// removeKeyboard убирает клавиатуру на шагах, где ответ вводится текстом
+var removeKeyboard = &tele.ReplyMarkup{RemoveKeyboard: true}
+
+// choices — одноразовая клавиатура с вариантами ответа, по perRow в ряд
+func choices(perRow int, options ...string) *tele.ReplyMarkup {
+	markup := &tele.ReplyMarkup{ResizeKeyboard: true, OneTimeKeyboard: true}
+
+	var rows []tele.Row
+	for i := 0; i < len(options); i += perRow {
+		var row tele.Row
+		for _, o := range options[i:min(i+perRow, len(options))] {
+			row = append(row, markup.Text(o))
+		}
+		rows = append(rows, row)
+	}
+	markup.Reply(rows...)
+	return markup
+}
@@ -0,0 +1,90 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/conversation"
+)
+
+func TestDialogsPerGroupMember(t *testing.T) {
+	app, _ := newTestBot(t)
+
+	send(app, testGroup, testUser, "/remind")
+	send(app, testGroup, testFriend, "/remind")
+
+	for _, u := range []int64{testUser.ID, testFriend.ID} {
+		st, ok, err := app.conversations.Get(testGroup.ID, u)
+		if err != nil || !ok || st.Step != "date" {
+			t.Fatalf("диалог %d: %+v, %v, %v", u, st, ok, err)
+		}
+	}
+
+	// Ответ Ann двигает только её диалог
+	m := message(testGroup, testUser, "завтра")
+	m.ReplyTo = &tele.Message{ID: 1, Sender: app.bot.Me, Chat: testGroup}
+	process(app, m)
+	if st, _, _ := app.conversations.Get(testGroup.ID, testUser.ID); st.Step == "date" {
+		t.Errorf("диалог Ann не перешёл к следующему шагу: %+v", st)
+	}
+	if st, _, _ := app.conversations.Get(testGroup.ID, testFriend.ID); st.Step != "date" {
+		t.Errorf("диалог Bob сдвинулся: %+v", st)
+	}
+
+	// /cancel Bob не трогает диалог Ann
+	send(app, testGroup, testFriend, "/cancel")
+	if _, ok, _ := app.conversations.Get(testGroup.ID, testFriend.ID); ok {
+		t.Error("диалог Bob не отменён")
+	}
+	if _, ok, _ := app.conversations.Get(testGroup.ID, testUser.ID); !ok {
+		t.Error("/cancel Bob отменил диалог Ann")
+	}
+}
+
+// countingConversations считает чтения хранилища диалогов
+type countingConversations struct {
+	conversation.Storage
+	gets int
+}
+
+func (s *countingConversations) Get(chatID, userID int64) (conversation.State, bool, error) {
+	s.gets++
+	return s.Storage.Get(chatID, userID)
+}
+
+func TestDialogIndexSkipsStorage(t *testing.T) {
+	app, tg := newTestBot(t)
+	counting := &countingConversations{Storage: app.conversations}
+	app.conversations = counting
+
+	// Пока индекс не полон (вебхук или первые минуты после запуска), хранилище читается
+	send(app, privateChat, testUser, "привет")
+	if counting.gets != 1 {
+		t.Fatalf("без полного индекса прочитано %d раз, ожидался один", counting.gets)
+	}
+
+	app.dialogs.trustAfter(time.Now().Add(-time.Second))
+	counting.gets = 0
+	send(app, privateChat, testUser, "привет")
+	send(app, testGroup, testUser, "всем привет")
+	if counting.gets != 0 {
+		t.Errorf("сообщения вне диалога прочитали хранилище %d раз", counting.gets)
+	}
+
+	// Диалог, начатый этим процессом, индекс знает
+	send(app, privateChat, testUser, "/remind")
+	tg.reset()
+	send(app, privateChat, testUser, "завтра")
+	if counting.gets == 0 || len(tg.texts()) == 0 {
+		t.Errorf("ответ в диалоге не обработан: чтений %d, ответы %q", counting.gets, tg.texts())
+	}
+
+	send(app, privateChat, testUser, "/cancel")
+	counting.gets = 0
+	send(app, privateChat, testUser, "привет")
+	if counting.gets != 0 {
+		t.Errorf("после отмены прочитано %d раз", counting.gets)
+	}
+}
@@ -0,0 +1,126 @@
+package bot
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/reminders"
+)
+
+const (
+	deliveryLease      = 2 * time.Minute  // на столько напоминание захватывается на время отправки
+	retryBaseDelay     = 30 * time.Second // первая пауза перед повтором, дальше удваивается
+	retryMaxDelay      = time.Hour
+	maxDeliveryAttempt = 8 // после стольких неудач напоминание считается недоставленным
+)
+
+// sendDueReminders отправляет все напоминания, у которых r.Time <= now,
+// и возвращает количество успешно отправленных
+func (app *BotApp) sendDueReminders(now time.Time) int {
+	due := app.storage.Claim(now, deliveryLease)
+
+	sent := 0
+	for _, r := range due {
+		if app.deliverReminder(r, now) {
+			sent++
+		}
+	}
+	return sent
+}
+
+// deliverReminder отправляет одно напоминание и сообщает хранилищу результат:
+// успех — подтверждение (или перенос повторяющегося), временная ошибка — повтор
+// с экспоненциальной паузой, постоянная — перевод в «недоставленные»
+func (app *BotApp) deliverReminder(r reminders.Reminder, now time.Time) bool {
+	err := app.sendReminder(r)
+	if err == nil {
+		if r.Repeat != reminders.RepeatNone {
+			err = app.storage.Reschedule(r.ID, app.nextOccurrence(r, now))
+		} else {
+			err = app.storage.Ack(r.ID)
+		}
+		if err != nil {
+			// Напоминание отправлено, но захват истечёт и оно уйдёт повторно
+			log.Printf("Не удалось подтвердить доставку напоминания %s: %v", r.ID, err)
+		}
+		return true
+	}
+
+	log.Printf("Не удалось отправить напоминание пользователю %d (попытка %d): %v", r.ChatID, r.Attempts, err)
+
+	if isPermanentSendError(err) || r.Attempts >= maxDeliveryAttempt {
+		if err := app.storage.Fail(r.ID, err.Error()); err != nil {
+			log.Printf("Не удалось пометить напоминание %s недоставленным: %v", r.ID, err)
+		}
+		return false
+	}
+
+	if err := app.storage.Retry(r.ID, now.Add(retryDelay(err, r.Attempts)), err.Error()); err != nil {
+		log.Printf("Не удалось запланировать повтор напоминания %s: %v", r.ID, err)
+	}
+	return false
+}
+
+// sendReminder отправляет текст напоминания. Если напоминание создано ответом
+// на сообщение, в том же чате оно приходит ответом на него, а в другом чате
+// исходное сообщение пересылается следом.
+func (app *BotApp) sendReminder(r reminders.Reminder) error {
+	to := &tele.Chat{ID: r.ChatID}
+	opts := &tele.SendOptions{}
+
+	sameChat := r.SourceMessageID != 0 && r.SourceChatID == r.ChatID
+	if sameChat {
+		opts.ReplyParams = &tele.ReplyParams{MessageID: r.SourceMessageID, AllowWithoutReply: true}
+	}
+
+	if _, err := app.bot.Send(to, reminderMessage(app.prefs(r.ChatID), r), opts); err != nil {
+		return err
+	}
+
+	if r.SourceMessageID != 0 && !sameChat {
+		source := tele.StoredMessage{MessageID: strconv.Itoa(r.SourceMessageID), ChatID: r.SourceChatID}
+		if _, err := app.bot.Forward(to, source); err != nil {
+			// Исходное сообщение могли удалить — само напоминание уже доставлено
+			log.Printf("Не удалось переслать исходное сообщение напоминания %s: %v", r.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// isPermanentSendError определяет ошибки, при которых повторять отправку бессмысленно:
+// бот заблокирован, удалён из группы, чат не найден и т.п.
+func isPermanentSendError(err error) bool {
+	var tgErr *tele.Error
+	if errors.As(err, &tgErr) {
+		return tgErr.Code == 400 || tgErr.Code == 403
+	}
+
+	var groupErr tele.GroupError
+	if errors.As(err, &groupErr) {
+		return true // группа стала супергруппой, старый ID больше не работает
+	}
+
+	// Неизвестные telebot ошибки Telegram приходят строкой вида "telegram: ... (403)"
+	msg := err.Error()
+	return strings.HasPrefix(msg, "telegram:") && (strings.HasSuffix(msg, "(400)") || strings.HasSuffix(msg, "(403)"))
+}
+
+// retryDelay вычисляет паузу перед следующей попыткой
+func retryDelay(err error, attempt int) time.Duration {
+	var flood tele.FloodError
+	if errors.As(err, &flood) && flood.RetryAfter > 0 {
+		return time.Duration(flood.RetryAfter) * time.Second
+	}
+
+	delay := retryBaseDelay << max(attempt-1, 0)
+	if delay <= 0 || delay > retryMaxDelay {
+		return retryMaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,172 @@
+package bot
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/reminders"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		attempt int
+		want    time.Duration
+	}{
+		{"первая попытка", errors.New("timeout"), 1, 30 * time.Second},
+		{"вторая попытка", errors.New("timeout"), 2, time.Minute},
+		{"третья попытка", errors.New("timeout"), 3, 2 * time.Minute},
+		{"до захвата", errors.New("timeout"), 0, 30 * time.Second},
+		{"потолок", errors.New("timeout"), 8, time.Hour},
+		{"переполнение сдвига", errors.New("timeout"), 70, time.Hour},
+		{"flood control", tele.FloodError{RetryAfter: 5}, 3, 5 * time.Second},
+		{"flood без retry_after", tele.FloodError{}, 2, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryDelay(tt.err, tt.attempt); got != tt.want {
+				t.Errorf("retryDelay = %v, ожидалось %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSendErrors прогоняет ответы Telegram с ошибками через sendReminder:
+// так ошибки приходят в том виде, в каком их собирает telebot
+func TestSendErrors(t *testing.T) {
+	app, tg := newTestBot(t)
+
+	tests := []struct {
+		name      string
+		resp      apiError
+		permanent bool
+		delay     time.Duration // пауза перед второй попыткой
+	}{
+		{"бот заблокирован", apiError{Code: 403, Description: "Forbidden: bot was blocked by the user"}, true, 0},
+		{"неизвестный 403", apiError{Code: 403, Description: "Forbidden: something new"}, true, 0},
+		{"чат не найден", apiError{Code: 400, Description: "Bad Request: chat not found"}, true, 0},
+		{"группа стала супергруппой", apiError{
+			Code:        400,
+			Description: "Bad Request: group chat was upgraded to a supergroup chat",
+			Parameters:  map[string]any{"migrate_to_chat_id": -1001},
+		}, true, 0},
+		{"flood control", apiError{
+			Code:        429,
+			Description: "Too Many Requests: retry after 7",
+			Parameters:  map[string]any{"retry_after": 7},
+		}, false, 7 * time.Second},
+		{"ошибка сервера", apiError{Code: 500, Description: "Internal Server Error"}, false, time.Minute},
+		{"шлюз", apiError{Code: 502, Description: "Bad Gateway"}, false, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tg.fails[privateChat.ID] = tt.resp
+
+			err := app.sendReminder(reminders.Reminder{ID: "r", ChatID: privateChat.ID, Text: "полить цветы"})
+			if err == nil {
+				t.Fatal("отправка прошла без ошибки")
+			}
+			if got := isPermanentSendError(err); got != tt.permanent {
+				t.Fatalf("isPermanentSendError(%v) = %v", err, got)
+			}
+			if !tt.permanent {
+				if got := retryDelay(err, 2); got != tt.delay {
+					t.Errorf("retryDelay(%v) = %v, ожидалось %v", err, got, tt.delay)
+				}
+			}
+		})
+	}
+}
+
+func TestDeliverReminderPermanentError(t *testing.T) {
+	app, tg := newTestBot(t)
+	tg.fails[privateChat.ID] = apiError{Code: 403, Description: "Forbidden: bot was blocked by the user"}
+
+	now := time.Now()
+	if err := app.storage.Add(reminders.Reminder{ID: "r", ChatID: privateChat.ID, Text: "полить цветы", Time: now.Add(-time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+	if sent := app.sendDueReminders(now); sent != 0 {
+		t.Fatalf("отправлено %d напоминаний", sent)
+	}
+
+	list := app.storage.ListAll()
+	if len(list) != 1 || list[0].State != reminders.StateDead || list[0].LastError == "" {
+		t.Fatalf("напоминание после блокировки: %+v", list)
+	}
+	if due := app.storage.Claim(now.Add(24*time.Hour), deliveryLease); len(due) != 0 {
+		t.Error("недоставленное напоминание снова захвачено")
+	}
+}
+
+func TestDeliverReminderBackoff(t *testing.T) {
+	app, tg := newTestBot(t)
+	tg.fails[privateChat.ID] = apiError{Code: 500, Description: "Internal Server Error"}
+
+	now := time.Now()
+	if err := app.storage.Add(reminders.Reminder{ID: "r", ChatID: privateChat.ID, Text: "полить цветы", Time: now.Add(-time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Паузы удваиваются от retryBaseDelay, пока не кончатся попытки
+	for attempt := 1; attempt <= maxDeliveryAttempt; attempt++ {
+		if attempt > 1 {
+			if due := app.storage.Claim(now.Add(-time.Second), deliveryLease); len(due) != 0 {
+				t.Fatalf("попытка %d: напоминание захвачено раньше паузы", attempt)
+			}
+		}
+		if sent := app.sendDueReminders(now); sent != 0 {
+			t.Fatalf("попытка %d: отправлено %d напоминаний", attempt, sent)
+		}
+
+		list := app.storage.ListAll()
+		if len(list) != 1 {
+			t.Fatalf("попытка %d: в хранилище %d напоминаний", attempt, len(list))
+		}
+		got := list[0]
+		if got.Attempts != attempt || got.LastError == "" {
+			t.Fatalf("попытка %d: %+v", attempt, got)
+		}
+		if attempt == maxDeliveryAttempt {
+			if got.State != reminders.StateDead {
+				t.Errorf("после %d попыток состояние %q", attempt, got.State)
+			}
+			return
+		}
+		if got.State != reminders.StatePending {
+			t.Fatalf("попытка %d: состояние %q", attempt, got.State)
+		}
+		if want := now.Add(retryDelay(nil, attempt)); !got.LeaseUntil.Equal(want) {
+			t.Fatalf("попытка %d: повтор в %v, ожидался в %v", attempt, got.LeaseUntil, want)
+		}
+		now = got.LeaseUntil
+	}
+}
+
+func TestDeliverReminderRecovers(t *testing.T) {
+	app, tg := newTestBot(t)
+	tg.fails[privateChat.ID] = apiError{Code: 502, Description: "Bad Gateway"}
+
+	now := time.Now()
+	if err := app.storage.Add(reminders.Reminder{ID: "r", ChatID: privateChat.ID, Text: "полить цветы", Time: now.Add(-time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+	if sent := app.sendDueReminders(now); sent != 0 {
+		t.Fatalf("отправлено %d напоминаний", sent)
+	}
+
+	// Telegram снова доступен — повтор после паузы доставляет напоминание
+	delete(tg.fails, privateChat.ID)
+	if sent := app.sendDueReminders(now.Add(retryBaseDelay)); sent != 1 {
+		t.Fatalf("после паузы отправлено %d напоминаний", sent)
+	}
+	if list := app.storage.ListAll(); len(list) != 0 {
+		t.Errorf("после доставки осталось %d напоминаний", len(list))
+	}
+}
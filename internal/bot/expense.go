@@ -0,0 +1,121 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/conversation"
+	"tg-bot/internal/expenses"
+	"tg-bot/internal/i18n"
+)
+
+// maxCategoryLen — длина своей категории трат в символах
+const maxCategoryLen = 32
+
+// expenseFlow — запись траты: сумма → категория → день. Запускается командой /expense.
+func (app *BotApp) expenseFlow() flow {
+	return flow{
+		start: "amount",
+		steps: map[string]step{
+			"amount": {
+				ask: func(c tele.Context, st *conversation.State) error {
+					return app.prompt(c, st, app.prefsFor(c).t("expense.ask_amount"), removeKeyboard)
+				},
+				answer: func(c tele.Context, st *conversation.State, text string) (string, error) {
+					amount, err := strconv.ParseFloat(strings.ReplaceAll(text, ",", "."), 64)
+					if err != nil || amount <= 0 {
+						return "amount", app.prompt(c, st, app.prefsFor(c).t("expense.bad_amount"), nil)
+					}
+					st.Data["amount"] = strconv.FormatFloat(amount, 'f', 2, 64)
+					return "category", nil
+				},
+			},
+			"category": {
+				ask: func(c tele.Context, st *conversation.State) error {
+					p := app.prefsFor(c)
+					return app.prompt(c, st, p.t("expense.ask_category"), choices(3, strings.Split(p.t("expense.categories"), "|")...))
+				},
+				answer: func(c tele.Context, st *conversation.State, text string) (string, error) {
+					if text == "" || len([]rune(text)) > maxCategoryLen {
+						return "category", app.prompt(c, st, app.prefsFor(c).t("expense.bad_category", maxCategoryLen), nil)
+					}
+					st.Data["category"] = text
+					return "date", nil
+				},
+			},
+			"date": {
+				ask: func(c tele.Context, st *conversation.State) error {
+					p := app.prefsFor(c)
+					return app.prompt(c, st, p.t("expense.ask_date"), app.datePicker(p, "date", pickPast, time.Now().In(p.loc)))
+				},
+				answer: func(c tele.Context, st *conversation.State, text string) (string, error) {
+					p := app.prefsFor(c)
+					now := time.Now().In(p.loc)
+					day, err := parseDay(text, now, pickPast)
+					if err != nil || day.After(now) {
+						return "date", app.prompt(c, st, p.t("expense.bad_date"), nil)
+					}
+
+					// Трата за сегодня — текущим временем, за прошлые дни — серединой дня
+					when := now
+					if day.Format("2006-01-02") != now.Format("2006-01-02") {
+						when = day.Add(12 * time.Hour)
+					}
+
+					amount, _ := strconv.ParseFloat(st.Data["amount"], 64)
+					category := st.Data["category"]
+					e := expenses.Expense{ChatID: st.ChatID, UserID: st.UserID, Amount: amount, Category: category, Time: when}
+					if err := app.expenses.Add(e); err != nil {
+						log.Printf("Ошибка при добавлении траты: %v", err)
+						return "", c.Send(p.t("expense.save_failed"), app.menuMarkup(c, p, mainMenu))
+					}
+					return "", c.Send(p.t("expense.saved", p.money(amount, "BYN"), category, p.date(when)), app.menuMarkup(c, p, mainMenu))
+				},
+			},
+		},
+	}
+}
+
+// handleExpense запускает пошаговую запись траты: /expense
+func (app *BotApp) handleExpense(c tele.Context) error {
+	return app.startFlow(c, "expense")
+}
+
+// handleExpenses показывает траты чата за текущий месяц по категориям
+func (app *BotApp) handleExpenses(c tele.Context) error {
+	p := app.prefsFor(c)
+	now := time.Now().In(p.loc)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	list := app.expenses.ListByChat(c.Chat().ID, monthStart)
+	if len(list) == 0 {
+		return c.Send(p.t("expense.none"))
+	}
+
+	byCategory := make(map[string]float64)
+	total := 0.0
+	for _, e := range list {
+		byCategory[e.Category] += e.Amount
+		total += e.Amount
+	}
+
+	categories := make([]string, 0, len(byCategory))
+	for cat := range byCategory {
+		categories = append(categories, cat)
+	}
+	sort.Slice(categories, func(i, j int) bool { return byCategory[categories[i]] > byCategory[categories[j]] })
+
+	var b strings.Builder
+	b.WriteString(p.t("expense.since", i18n.DayMonth(p.lang, monthStart)))
+	for _, cat := range categories {
+		fmt.Fprintf(&b, "• %s — %s\n", cat, p.money(byCategory[cat], "BYN"))
+	}
+	b.WriteString(p.t("expense.total", p.money(total, "BYN")))
+	return c.Send(b.String())
+}
@@ -0,0 +1,184 @@
+package bot
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/reminders"
+)
+
+// geoDeleteBtn удаляет напоминание по месту; данные кнопки — ID напоминания
+var geoDeleteBtn = tele.Btn{Unique: "geo_del"}
+
+// Радиус срабатывания напоминания по месту, метры
+const (
+	defaultGeoRadius = 200
+	minGeoRadius     = 50
+	maxGeoRadius     = 5000
+)
+
+// geoRequest — разобранная команда /remind_at
+type geoRequest struct {
+	lat, lon float64
+	hasPoint bool // координаты указаны в самой команде
+	radius   float64
+	text     string
+}
+
+// parseRemindAt разбирает аргументы /remind_at: необязательные координаты,
+// необязательный радиус в метрах и текст
+func parseRemindAt(p chatPrefs, payload string) (geoRequest, error) {
+	req := geoRequest{radius: defaultGeoRadius}
+	fields := strings.Fields(payload)
+
+	if len(fields) >= 2 {
+		lat, errLat := strconv.ParseFloat(fields[0], 64)
+		lon, errLon := strconv.ParseFloat(fields[1], 64)
+		if errLat == nil && errLon == nil && strings.Contains(fields[0], ".") {
+			if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+				return req, errors.New(p.t("geo.bad_coords"))
+			}
+			req.lat, req.lon, req.hasPoint = lat, lon, true
+			fields = fields[2:]
+		}
+	}
+
+	if len(fields) > 0 {
+		if r, err := strconv.Atoi(fields[0]); err == nil {
+			if r < minGeoRadius || r > maxGeoRadius {
+				return req, errors.New(p.t("geo.bad_radius", minGeoRadius, maxGeoRadius))
+			}
+			req.radius = float64(r)
+			fields = fields[1:]
+		}
+	}
+
+	req.text = strings.Join(fields, " ")
+	if req.text == "" {
+		return req, errors.New(p.t("geo.no_text"))
+	}
+	return req, nil
+}
+
+// handleRemindAt создаёт напоминание, привязанное к месту.
+// Точка берётся из сообщения с геопозицией, на которое ответили, или из аргументов.
+func (app *BotApp) handleRemindAt(c tele.Context) error {
+	m := c.Message()
+	p := app.prefsFor(c)
+
+	req, err := parseRemindAt(p, m.Payload)
+	if err != nil {
+		return c.Send(err.Error() + "\n\n" + p.t("geo.usage"))
+	}
+
+	if !req.hasPoint {
+		if m.ReplyTo == nil || m.ReplyTo.Location == nil {
+			return c.Send(p.t("geo.usage"))
+		}
+		req.lat, req.lon = float64(m.ReplyTo.Location.Lat), float64(m.ReplyTo.Location.Lng)
+	}
+
+	rem := reminders.GeoReminder{
+		ChatID:   m.Chat.ID,
+		AuthorID: m.Sender.ID,
+		Text:     req.text,
+		Lat:      req.lat,
+		Lon:      req.lon,
+		Radius:   req.radius,
+	}
+	if err := app.geo.Add(rem); err != nil {
+		log.Printf("Ошибка при добавлении напоминания по месту: %v", err)
+		return c.Send(p.t("remind.save_failed"))
+	}
+
+	return c.Send(p.t("geo.saved", req.text, int(req.radius)))
+}
+
+// handleLiveLocation обрабатывает обновления трансляции геопозиции:
+// Telegram присылает их как отредактированное сообщение
+func (app *BotApp) handleLiveLocation(c tele.Context) error {
+	m := c.Message()
+	if m == nil || m.Location == nil || m.Sender == nil {
+		return nil
+	}
+
+	return app.checkGeoReminders(c, m.Sender.ID, m.Location)
+}
+
+// checkGeoReminders отправляет и удаляет напоминания автора, в радиус которых он попал
+func (app *BotApp) checkGeoReminders(c tele.Context, authorID int64, loc *tele.Location) error {
+	lat, lon := float64(loc.Lat), float64(loc.Lng)
+
+	for _, r := range app.geo.ListByChat(c.Chat().ID) {
+		if r.AuthorID != authorID || !r.Near(lat, lon) {
+			continue
+		}
+
+		// Удаляем до отправки: следующее обновление трансляции не должно повторить напоминание
+		if err := app.geo.Delete(r.ChatID, r.ID); err != nil {
+			log.Printf("Не удалось удалить напоминание по месту %s: %v", r.ID, err)
+			continue
+		}
+		if err := c.Send(geoReminderMessage(app.prefsFor(c), r, c.Sender())); err != nil {
+			log.Printf("Не удалось отправить напоминание по месту %s: %v", r.ID, err)
+		}
+	}
+	return nil
+}
+
+// geoDeleteButtons — кнопки удаления напоминаний по месту под списком /reminders
+func geoDeleteButtons(p chatPrefs, list []reminders.GeoReminder) *tele.ReplyMarkup {
+	if len(list) == 0 {
+		return nil
+	}
+
+	markup := &tele.ReplyMarkup{}
+	rows := make([]tele.Row, 0, len(list))
+	for _, r := range list {
+		rows = append(rows, markup.Row(markup.Data(p.t("reminders.place_delete", shorten(r.Text, 30)), geoDeleteBtn.Unique, r.ID)))
+	}
+	markup.Inline(rows...)
+	return markup
+}
+
+// handleGeoDelete удаляет напоминание по месту по кнопке из /reminders.
+// Удалить напоминание может только его автор.
+func (app *BotApp) handleGeoDelete(c tele.Context) error {
+	p := app.prefsFor(c)
+	chatID := c.Chat().ID
+
+	var target *reminders.GeoReminder
+	for _, r := range app.geo.ListByChat(chatID) {
+		if r.ID == c.Data() {
+			target = &r
+			break
+		}
+	}
+	switch {
+	case target == nil:
+		c.Respond(&tele.CallbackResponse{Text: p.t("geo.not_found")})
+	case target.AuthorID != c.Sender().ID:
+		return c.Respond(&tele.CallbackResponse{Text: p.t("geo.not_yours")})
+	default:
+		if err := app.geo.Delete(chatID, target.ID); err != nil && !errors.Is(err, reminders.ErrNotFound) {
+			log.Printf("Не удалось удалить напоминание по месту %s: %v", target.ID, err)
+			return c.Respond(&tele.CallbackResponse{Text: p.t("geo.delete_failed")})
+		}
+		c.Respond(&tele.CallbackResponse{Text: p.t("geo.deleted")})
+	}
+
+	msg, markup := app.remindersList(c, p)
+	return c.Edit(msg, markup)
+}
+
+// geoReminderMessage формирует текст сработавшего напоминания по месту
+func geoReminderMessage(p chatPrefs, r reminders.GeoReminder, sender *tele.User) string {
+	if r.ChatID != r.AuthorID && sender != nil && sender.Username != "" {
+		return p.t("geo.delivered_for", sender.Username, r.Text)
+	}
+	return p.t("geo.delivered", r.Text)
+}
@@ -0,0 +1,73 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+
+	"tg-bot/internal/i18n"
+	"tg-bot/internal/reminders"
+)
+
+func TestGeoDeleteButton(t *testing.T) {
+	app, tg := newTestBot(t)
+
+	for _, r := range []reminders.GeoReminder{
+		{ID: "mine", ChatID: testGroup.ID, AuthorID: testUser.ID, Text: "молоко", Radius: 200},
+		{ID: "bobs", ChatID: testGroup.ID, AuthorID: testFriend.ID, Text: "почта", Radius: 200},
+	} {
+		if err := app.geo.Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	send(app, testGroup, testUser, "/reminders")
+	list := tg.sent("sendMessage")
+	if len(list) != 1 {
+		t.Fatalf("отправлено %d сообщений", len(list))
+	}
+	markup, _ := list[0].Params["reply_markup"].(string)
+	for _, id := range []string{"geo_del|mine", "geo_del|bobs"} {
+		if !strings.Contains(markup, id) {
+			t.Errorf("под списком нет кнопки %s: %s", id, markup)
+		}
+	}
+
+	tg.reset()
+	press(app, testGroup, testUser, geoDeleteBtn, "bobs")
+	if got := callbackText(tg); got != i18n.T("ru", "geo.not_yours") {
+		t.Errorf("ответ на чужое напоминание %q", got)
+	}
+	if n := len(app.geo.ListByChat(testGroup.ID)); n != 2 {
+		t.Fatalf("удалено чужое напоминание: осталось %d", n)
+	}
+
+	tg.reset()
+	press(app, testGroup, testUser, geoDeleteBtn, "mine")
+	if got := callbackText(tg); got != i18n.T("ru", "geo.deleted") {
+		t.Errorf("ответ на удаление %q", got)
+	}
+	left := app.geo.ListByChat(testGroup.ID)
+	if len(left) != 1 || left[0].ID != "bobs" {
+		t.Fatalf("осталось %+v", left)
+	}
+	edits := tg.sent("editMessageText")
+	if len(edits) != 1 || strings.Contains(edits[0].Text(), "молоко") || !strings.Contains(edits[0].Text(), "почта") {
+		t.Errorf("список не обновлён: %+v", edits)
+	}
+
+	tg.reset()
+	press(app, testGroup, testUser, geoDeleteBtn, "mine")
+	if got := callbackText(tg); got != i18n.T("ru", "geo.not_found") {
+		t.Errorf("повторное нажатие: %q", got)
+	}
+}
+
+// callbackText возвращает текст ответа на нажатие кнопки
+func callbackText(tg *fakeTelegram) string {
+	answers := tg.sent("answerCallbackQuery")
+	if len(answers) == 0 {
+		return ""
+	}
+	s, _ := answers[0].Params["text"].(string)
+	return s
+}
@@ -0,0 +1,121 @@
+package bot
+
+import (
+	"log"
+	"strings"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// groupAnonymousBot — от имени этого пользователя Telegram присылает сообщения
+// администраторов, которые пишут в группу анонимно
+const groupAnonymousBot = 1087968824
+
+// groupFilter пропускает в группах только сообщения, обращённые к боту, а также
+// трансляции геопозиции (для напоминаний по месту) и файлы .ics (для импорта).
+// Колбеки, inline-запросы и обновления трансляций проходят как есть.
+func (app *BotApp) groupFilter(next tele.HandlerFunc) tele.HandlerFunc {
+	return func(c tele.Context) error {
+		m := c.Update().Message
+		if m == nil || !m.FromGroup() || app.addressedToBot(m) || groupShared(m) {
+			return next(c)
+		}
+		return nil
+	}
+}
+
+// groupShared сообщает, что сообщение группы нужно боту, даже если обращено не к нему:
+// начало трансляции геопозиции или файл .ics. Обычная геопозиция меняет место чата,
+// поэтому учитывается, только если это ответ боту.
+func groupShared(m *tele.Message) bool {
+	return (m.Location != nil && m.Location.LivePeriod > 0) || isICS(m.Document)
+}
+
+// addressedToBot сообщает, обращено ли сообщение группы к боту: команда, упоминание,
+// ответ на сообщение бота или нажатие кнопки его меню. Ответы на шагах диалога тоже
+// приходят ответом на вопрос бота (см. prompt), поэтому хранилище диалогов здесь не читается.
+// Команды другим ботам («/help@other_bot») telebot отбрасывает ещё до middleware.
+func (app *BotApp) addressedToBot(m *tele.Message) bool {
+	switch {
+	case strings.HasPrefix(m.Text, "/"):
+		return true
+	case m.ReplyTo != nil && m.ReplyTo.Sender != nil && m.ReplyTo.Sender.ID == app.bot.Me.ID:
+		return true
+	case app.menuButtons[m.Text]:
+		return true
+	}
+	return app.mentionsBot(m)
+}
+
+// mentionsBot сообщает, упомянут ли бот в тексте или подписи сообщения:
+// по @username или ссылкой на пользователя
+func (app *BotApp) mentionsBot(m *tele.Message) bool {
+	entities := m.Entities
+	if m.Text == "" {
+		entities = m.CaptionEntities
+	}
+
+	for _, e := range entities {
+		switch e.Type {
+		case tele.EntityMention:
+			if strings.EqualFold(m.EntityText(e), "@"+app.bot.Me.Username) {
+				return true
+			}
+		case tele.EntityTMention:
+			if e.User != nil && e.User.ID == app.bot.Me.ID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isChatAdmin сообщает, может ли отправитель менять настройки чата:
+// в личном чате — всегда, в группе — создатель и администраторы, в том числе анонимные
+func (app *BotApp) isChatAdmin(c tele.Context) bool {
+	chat, sender := c.Chat(), c.Sender()
+	if chat == nil || chat.Type == tele.ChatPrivate {
+		return true
+	}
+	if sender == nil {
+		return false
+	}
+	if sender.ID == groupAnonymousBot {
+		m := c.Message()
+		return m != nil && m.SenderChat != nil && m.SenderChat.ID == chat.ID
+	}
+
+	member, err := app.bot.ChatMemberOf(chat, sender)
+	if err != nil {
+		log.Printf("Не удалось проверить права %d в чате %d: %v", sender.ID, chat.ID, err)
+		return false
+	}
+	return member.Role == tele.Creator || member.Role == tele.Administrator
+}
+
+// adminOnly возвращает обработчик, который в группах пропускает к next только администраторов
+func (app *BotApp) adminOnly(next tele.HandlerFunc) tele.HandlerFunc {
+	return func(c tele.Context) error {
+		if app.isChatAdmin(c) {
+			return next(c)
+		}
+
+		p := app.prefsFor(c)
+		if c.Callback() != nil {
+			return c.Respond(&tele.CallbackResponse{Text: p.t("group.admins_only"), ShowAlert: true})
+		}
+		return c.Reply(p.t("group.admins_only"))
+	}
+}
+
+// chatTitle возвращает название группы для списков; если Telegram его не отдал — заглушку
+func (app *BotApp) chatTitle(p chatPrefs, chatID int64) string {
+	chat, err := app.bot.ChatByID(chatID)
+	if err != nil || chat.Title == "" {
+		if err != nil {
+			log.Printf("Не удалось получить чат %d: %v", chatID, err)
+		}
+		return p.t("group.untitled")
+	}
+	return chat.Title
+}
@@ -0,0 +1,69 @@
+package bot
+
+import (
+	"testing"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/i18n"
+)
+
+func TestGroupFilter(t *testing.T) {
+	app, _ := newTestBot(t)
+	counting := &countingConversations{Storage: app.conversations}
+	app.conversations = counting
+
+	fromBot := &tele.Message{ID: 1, Chat: testGroup, Sender: app.bot.Me}
+	fromOther := &tele.Message{ID: 2, Chat: testGroup, Sender: testFriend}
+	mention := message(testGroup, testUser, "@test_bot привет")
+	mention.Entities = tele.Entities{{Type: tele.EntityMention, Offset: 0, Length: len("@test_bot")}}
+
+	tests := []struct {
+		name string
+		m    *tele.Message
+		pass bool
+	}{
+		{"команда", message(testGroup, testUser, "/weather"), true},
+		{"упоминание", mention, true},
+		{"кнопка меню", message(testGroup, testUser, i18n.T("ru", "menu.weather")), true},
+		{"ответ боту", &tele.Message{Chat: testGroup, Sender: testUser, Text: "завтра", ReplyTo: fromBot}, true},
+		{"трансляция геопозиции", &tele.Message{Chat: testGroup, Sender: testUser, Location: &tele.Location{Lat: 53.9, Lng: 27.5, LivePeriod: 900}}, true},
+		{"файл .ics", &tele.Message{Chat: testGroup, Sender: testUser, Document: &tele.Document{FileName: "team.ICS"}}, true},
+		{"обычный текст", message(testGroup, testUser, "всем привет"), false},
+		{"ответ другому участнику", &tele.Message{Chat: testGroup, Sender: testUser, Text: "да", ReplyTo: fromOther}, false},
+		{"обычная геопозиция", &tele.Message{Chat: testGroup, Sender: testUser, Location: &tele.Location{Lat: 53.9, Lng: 27.5}}, false},
+		{"другой файл", &tele.Message{Chat: testGroup, Sender: testUser, Document: &tele.Document{FileName: "notes.txt"}}, false},
+		{"личный чат", message(privateChat, testUser, "привет"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passed := false
+			filter := app.groupFilter(func(tele.Context) error { passed = true; return nil })
+			if err := filter(app.bot.NewContext(tele.Update{Message: tt.m})); err != nil {
+				t.Fatal(err)
+			}
+			if passed != tt.pass {
+				t.Errorf("пропущено: %v, ожидалось %v", passed, tt.pass)
+			}
+		})
+	}
+
+	if counting.gets != 0 {
+		t.Errorf("фильтр прочитал хранилище диалогов %d раз", counting.gets)
+	}
+}
+
+func TestGroupFilterPassesEditedLocation(t *testing.T) {
+	app, _ := newTestBot(t)
+
+	passed := false
+	filter := app.groupFilter(func(tele.Context) error { passed = true; return nil })
+	edited := &tele.Message{Chat: testGroup, Sender: testUser, Location: &tele.Location{Lat: 53.9, Lng: 27.5, LivePeriod: 900}}
+	if err := filter(app.bot.NewContext(tele.Update{EditedMessage: edited})); err != nil {
+		t.Fatal(err)
+	}
+	if !passed {
+		t.Error("обновление трансляции отброшено")
+	}
+}
@@ -0,0 +1,355 @@
+package bot
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/ical"
+	"tg-bot/internal/reminders"
+)
+
+const (
+	// xSunsetOffset — смещение от заката в минутах для напоминаний /remind_sunset.
+	// В RRULE такое правило не выразить, поэтому оно экспортируется как FREQ=DAILY
+	// с этим свойством, и при импорте обратно восстанавливается.
+	xSunsetOffset = "X-TG-BOT-SUNSET-OFFSET"
+
+	maxICSSize      = 1 << 20 // максимальный размер импортируемого файла
+	maxICSEvents    = 100     // сколько событий можно импортировать за раз
+	maxPreviewLines = 15      // сколько событий показывать в предпросмотре
+)
+
+// Кнопки подтверждения импорта. Состояние не хранится: предпросмотр отправляется
+// ответом на документ, и по нажатию файл читается заново из этого ответа.
+var (
+	icsImportBtn = tele.Btn{Unique: "ics_import"}
+	icsCancelBtn = tele.Btn{Unique: "ics_cancel"}
+)
+
+// repeatRRules — соответствие календарных правил повторения и RRULE
+var repeatRRules = map[reminders.Repeat]string{
+	reminders.RepeatDaily:   "FREQ=DAILY",
+	reminders.RepeatWeekly:  "FREQ=WEEKLY",
+	reminders.RepeatMonthly: "FREQ=MONTHLY",
+	reminders.RepeatYearly:  "FREQ=YEARLY",
+	reminders.RepeatSunset:  "FREQ=DAILY",
+}
+
+// handleExport выгружает напоминания чата: /export reminders
+func (app *BotApp) handleExport(c tele.Context) error {
+	p := app.prefsFor(c)
+	if strings.TrimSpace(c.Message().Payload) != "reminders" {
+		return c.Send(p.t("ics.export_usage"))
+	}
+
+	var events []ical.Event
+	for _, r := range app.chatReminders(c.Chat().ID) {
+		if r.State == reminders.StateDead {
+			continue
+		}
+		events = append(events, reminderEvent(r))
+	}
+	if len(events) == 0 {
+		return c.Send(p.t("ics.export_empty"))
+	}
+
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, ical.ProdID, events); err != nil {
+		log.Printf("Не удалось сформировать календарь: %v", err)
+		return c.Send(p.t("ics.export_failed"))
+	}
+
+	return c.Send(&tele.Document{
+		File:     tele.FromReader(&buf),
+		FileName: "reminders.ics",
+		MIME:     "text/calendar",
+		Caption:  p.n("ics.export_caption", len(events)),
+	})
+}
+
+// reminderEvent превращает напоминание в событие календаря. Напоминание из
+// события календаря сохраняет его UID, остальные получают UID по своему ID.
+func reminderEvent(r reminders.Reminder) ical.Event {
+	e := ical.Event{
+		UID:     r.UID,
+		Summary: r.Text,
+		Start:   r.Time,
+		RRule:   repeatRRules[r.Repeat],
+	}
+	if e.UID == "" {
+		e.UID = r.ID + "@tg-bot"
+	}
+	if r.Repeat == reminders.RepeatSunset {
+		e.Extra = map[string]string{xSunsetOffset: strconv.Itoa(int(r.Offset / time.Minute))}
+	}
+	return e
+}
+
+// icsImport — результат разбора календаря для импорта
+type icsImport struct {
+	reminders   []reminders.Reminder
+	past        int // однократные события, которые уже прошли
+	duplicates  int // события, уже импортированные в этот чат
+	unsupported int // события с правилом повторения, которое импортируется как однократное
+	tooMany     int // события сверх maxICSEvents
+}
+
+// isICS сообщает, похож ли документ на календарь
+func isICS(doc *tele.Document) bool {
+	return doc != nil && (doc.MIME == "text/calendar" || strings.EqualFold(path.Ext(doc.FileName), ".ics"))
+}
+
+// handleDocument показывает предпросмотр импорта для присланного файла .ics
+func (app *BotApp) handleDocument(c tele.Context) error {
+	m := c.Message()
+	if !isICS(m.Document) {
+		return nil
+	}
+
+	p := app.prefsFor(c)
+	imp, err := app.readICS(p, m, time.Now().In(p.loc))
+	if err != nil {
+		return c.Reply(err.Error())
+	}
+	if len(imp.reminders) == 0 {
+		if imp.duplicates > 0 && imp.past == 0 {
+			return c.Reply(p.t("ics.already_imported"))
+		}
+		return c.Reply(p.t("ics.nothing_to_import") + imp.notes(p))
+	}
+
+	var b strings.Builder
+	b.WriteString(p.n("ics.preview", len(imp.reminders)))
+	for i, r := range imp.reminders {
+		if i == maxPreviewLines {
+			b.WriteString(p.t("ics.preview_more", len(imp.reminders)-maxPreviewLines))
+			break
+		}
+		b.WriteString(reminderLine(p, r) + "\n")
+	}
+	b.WriteString(imp.notes(p))
+
+	markup := &tele.ReplyMarkup{}
+	markup.Inline(markup.Row(
+		markup.Data(p.t("ics.import_btn"), icsImportBtn.Unique),
+		markup.Data(p.t("ics.cancel_btn"), icsCancelBtn.Unique),
+	))
+	return c.Reply(b.String(), markup)
+}
+
+// handleICSImport создаёт напоминания из файла, к которому относится предпросмотр
+func (app *BotApp) handleICSImport(c tele.Context) error {
+	p := app.prefsFor(c)
+	preview := c.Callback().Message
+	if preview == nil || preview.ReplyTo == nil || !isICS(preview.ReplyTo.Document) {
+		return c.Respond(&tele.CallbackResponse{Text: p.t("ics.file_not_found")})
+	}
+	if src := preview.ReplyTo.Sender; src != nil && src.ID != c.Sender().ID {
+		return c.Respond(&tele.CallbackResponse{Text: p.t("ics.not_yours")})
+	}
+
+	imp, err := app.readICS(p, preview.ReplyTo, time.Now().In(p.loc))
+	if err != nil {
+		return c.Edit(err.Error())
+	}
+
+	added := 0
+	for _, r := range imp.reminders {
+		if err := app.storage.Add(r); err != nil {
+			log.Printf("Ошибка при импорте напоминания: %v", err)
+			continue
+		}
+		added++
+	}
+
+	c.Respond()
+	msg := p.n("ics.imported", added)
+	if added < len(imp.reminders) {
+		msg += p.t("ics.import_failed", len(imp.reminders)-added)
+	}
+	return c.Edit(msg + p.t("ics.list_hint"))
+}
+
+// handleICSCancel отменяет импорт
+func (app *BotApp) handleICSCancel(c tele.Context) error {
+	c.Respond()
+	return c.Edit(app.prefsFor(c).t("ics.import_cancelled"))
+}
+
+// readICS скачивает календарь из сообщения и превращает будущие события в напоминания
+func (app *BotApp) readICS(p chatPrefs, m *tele.Message, now time.Time) (icsImport, error) {
+	var imp icsImport
+
+	if m.Document.FileSize > maxICSSize {
+		return imp, errors.New(p.t("ics.too_big", maxICSSize>>10))
+	}
+
+	body, err := app.bot.File(&m.Document.File)
+	if err != nil {
+		log.Printf("Не удалось скачать файл %s: %v", m.Document.FileID, err)
+		return imp, errors.New(p.t("ics.download_failed"))
+	}
+	defer body.Close()
+
+	events, err := ical.Parse(body, now.Location())
+	if err != nil {
+		return imp, errors.New(p.t("ics.parse_failed", err))
+	}
+	return app.importEvents(p, m, events, now), nil
+}
+
+// importEvents превращает будущие события календаря в напоминания чата сообщения m
+func (app *BotApp) importEvents(p chatPrefs, m *tele.Message, events []ical.Event, now time.Time) icsImport {
+	var imp icsImport
+
+	// UID уже импортированных и выгруженных через /export событий: повторный
+	// импорт того же файла не должен удваивать напоминания
+	seen := make(map[string]bool)
+	for _, r := range app.chatReminders(m.Chat.ID) {
+		seen[r.ID+"@tg-bot"] = true
+		if r.UID != "" {
+			seen[r.UID] = true
+		}
+	}
+
+	for _, e := range events {
+		if !e.Active() {
+			continue
+		}
+		if e.UID != "" {
+			if seen[e.UID] {
+				imp.duplicates++
+				continue
+			}
+			seen[e.UID] = true
+		}
+		r, supported := eventReminder(e, p.t("ics.untitled"))
+		r.ChatID = m.Chat.ID
+		if m.Sender != nil {
+			r.AuthorID = m.Sender.ID
+		}
+		if !supported {
+			imp.unsupported++
+		}
+
+		r, ok := app.upcoming(r, now)
+		if !ok {
+			imp.past++
+			continue
+		}
+
+		if len(imp.reminders) == maxICSEvents {
+			imp.tooMany++
+			continue
+		}
+		imp.reminders = append(imp.reminders, r)
+	}
+
+	return imp
+}
+
+// upcoming переносит прошедшее повторяющееся напоминание на ближайшее срабатывание;
+// false — однократное напоминание уже прошло
+func (app *BotApp) upcoming(r reminders.Reminder, now time.Time) (reminders.Reminder, bool) {
+	if r.Time.After(now) {
+		return r, true
+	}
+	if r.Repeat == reminders.RepeatNone {
+		return r, false
+	}
+	r.Time = app.nextOccurrence(r, now)
+	return r, true
+}
+
+// eventReminder превращает событие календаря в напоминание. false — правило
+// повторения не поддерживается, и напоминание будет однократным.
+// Событие на весь день напоминает в 09:00, событие без названия получает текст untitled.
+func eventReminder(e ical.Event, untitled string) (reminders.Reminder, bool) {
+	r := reminders.Reminder{Text: e.Summary, Time: e.Start, UID: e.UID}
+	if r.Text == "" {
+		r.Text = untitled
+	}
+	if e.AllDay {
+		// time.Date, а не Add: в день перехода на летнее время в сутках не 24 часа
+		y, m, d := e.Start.Date()
+		r.Time = time.Date(y, m, d, 9, 0, 0, 0, e.Start.Location())
+	}
+
+	if e.RRule == "" {
+		return r, true
+	}
+
+	if offset, ok := e.Extra[xSunsetOffset]; ok && e.RRule == "FREQ=DAILY" {
+		minutes, err := strconv.Atoi(offset)
+		d := time.Duration(minutes) * time.Minute
+		if err == nil && d >= -maxSunsetOffset && d <= maxSunsetOffset {
+			r.Repeat, r.Offset = reminders.RepeatSunset, d
+			return r, true
+		}
+	}
+
+	repeat, ok := parseRRule(e.RRule)
+	if !ok {
+		return r, false
+	}
+	r.Repeat = repeat
+	if repeat == reminders.RepeatMonthly || repeat == reminders.RepeatYearly {
+		// День берётся из DTSTART, чтобы после коротких месяцев вернуться к нему
+		r.Day = r.Time.Day()
+	}
+	return r, true
+}
+
+// parseRRule понимает только простые правила: FREQ без интервала, количества
+// и ограничений (BYDAY и т.п.) — то, что умеют повторяющиеся напоминания
+func parseRRule(rule string) (reminders.Repeat, bool) {
+	var repeat reminders.Repeat
+	for _, part := range strings.Split(rule, ";") {
+		k, v, _ := strings.Cut(strings.ToUpper(part), "=")
+		switch k {
+		case "FREQ":
+			for rep, rr := range repeatRRules {
+				if rep != reminders.RepeatSunset && rr == "FREQ="+v {
+					repeat = rep
+				}
+			}
+		case "INTERVAL":
+			if v != "1" {
+				return reminders.RepeatNone, false
+			}
+		case "WKST":
+			// День начала недели не влияет на простые правила
+		default:
+			return reminders.RepeatNone, false
+		}
+	}
+	return repeat, repeat != reminders.RepeatNone
+}
+
+// notes описывает пропущенные при импорте события
+func (imp icsImport) notes(p chatPrefs) string {
+	var notes []string
+	if imp.past > 0 {
+		notes = append(notes, p.t("ics.note_past", imp.past))
+	}
+	if imp.duplicates > 0 {
+		notes = append(notes, p.t("ics.note_duplicates", imp.duplicates))
+	}
+	if imp.unsupported > 0 {
+		notes = append(notes, p.n("ics.note_unsupported", imp.unsupported))
+	}
+	if imp.tooMany > 0 {
+		notes = append(notes, p.t("ics.note_too_many", maxICSEvents, imp.tooMany))
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+	return "\n⚠️ " + strings.Join(notes, "\n⚠️ ")
+}
@@ -0,0 +1,115 @@
+package bot
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // Europe/Berlin для перехода на летнее время
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/ical"
+	"tg-bot/internal/reminders"
+	"tg-bot/internal/settings"
+)
+
+func TestImportEventsSkipsImportedUIDs(t *testing.T) {
+	app := &BotApp{
+		location: time.UTC,
+		storage:  reminders.NewMemoryStorage(),
+		settings: settings.NewMemoryStorage(),
+	}
+	p := app.prefs(testChatID)
+	m := &tele.Message{Chat: &tele.Chat{ID: testChatID}, Sender: &tele.User{ID: testChatID}}
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+
+	events := []ical.Event{
+		{UID: "rent", Summary: "Квартплата", Start: time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC), RRule: "FREQ=MONTHLY"},
+		{UID: "dentist", Summary: "Стоматолог", Start: now.Add(48 * time.Hour)},
+		{UID: "dentist", Summary: "Стоматолог (перенос)", Start: now.Add(72 * time.Hour)},
+	}
+
+	imp := app.importEvents(p, m, events, now)
+	if len(imp.reminders) != 2 || imp.duplicates != 1 {
+		t.Fatalf("импортировано %d, повторов %d; ожидалось 2 и 1", len(imp.reminders), imp.duplicates)
+	}
+	rent := imp.reminders[0]
+	if want := time.Date(2026, 2, 28, 10, 0, 0, 0, time.UTC); rent.Day != 31 || !rent.Time.Equal(want) {
+		t.Errorf("ежемесячное напоминание: день %d, время %v; ожидалось 31 и %v", rent.Day, rent.Time, want)
+	}
+	for _, r := range imp.reminders {
+		if err := app.storage.Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Повторный импорт того же файла ничего не добавляет
+	if again := app.importEvents(p, m, events, now); len(again.reminders) != 0 || again.duplicates != 3 {
+		t.Fatalf("повторный импорт: %d напоминаний, %d повторов; ожидалось 0 и 3", len(again.reminders), again.duplicates)
+	}
+
+	// Как и импорт файла, выгруженного из этого же чата через /export
+	exported := reminderEvent(reminders.Reminder{ID: app.chatReminders(testChatID)[0].ID, Text: "Квартплата", Time: now.Add(time.Hour)})
+	if imp := app.importEvents(p, m, []ical.Event{exported}, now); len(imp.reminders) != 0 {
+		t.Errorf("выгруженное напоминание импортировано повторно: %+v", imp.reminders)
+	}
+}
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		rule string
+		want reminders.Repeat
+		ok   bool
+	}{
+		{"FREQ=DAILY", reminders.RepeatDaily, true},
+		{"freq=weekly", reminders.RepeatWeekly, true},
+		{"FREQ=MONTHLY;INTERVAL=1", reminders.RepeatMonthly, true},
+		{"FREQ=YEARLY;WKST=MO", reminders.RepeatYearly, true},
+		{"WKST=SU;FREQ=WEEKLY", reminders.RepeatWeekly, true},
+		{"FREQ=DAILY;INTERVAL=2", reminders.RepeatNone, false},
+		{"FREQ=WEEKLY;BYDAY=MO,WE", reminders.RepeatNone, false},
+		{"FREQ=MONTHLY;COUNT=3", reminders.RepeatNone, false},
+		{"FREQ=YEARLY;UNTIL=20300101T000000Z", reminders.RepeatNone, false},
+		{"FREQ=HOURLY", reminders.RepeatNone, false},
+		{"INTERVAL=1", reminders.RepeatNone, false},
+		{"", reminders.RepeatNone, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRRule(tt.rule)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRRule(%q) = %q, %v; ожидалось %q, %v", tt.rule, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestEventReminder(t *testing.T) {
+	start := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		event     ical.Event
+		want      reminders.Reminder
+		supported bool
+	}{
+		{"однократное без названия", ical.Event{UID: "a", Start: start.Add(10 * time.Hour)},
+			reminders.Reminder{Text: "untitled", Time: start.Add(10 * time.Hour), UID: "a"}, true},
+		{"на весь день — в 9:00", ical.Event{Summary: "День рождения", Start: start, AllDay: true, RRule: "FREQ=YEARLY"},
+			reminders.Reminder{Text: "День рождения", Time: start.Add(9 * time.Hour), Repeat: reminders.RepeatYearly, Day: 31}, true},
+		{"ежемесячное с 31-го", ical.Event{Summary: "Квартплата", Start: start, RRule: "FREQ=MONTHLY"},
+			reminders.Reminder{Text: "Квартплата", Time: start, Repeat: reminders.RepeatMonthly, Day: 31}, true},
+		{"закат", ical.Event{Summary: "Теплица", Start: start, RRule: "FREQ=DAILY", Extra: map[string]string{xSunsetOffset: "-30"}},
+			reminders.Reminder{Text: "Теплица", Time: start, Repeat: reminders.RepeatSunset, Offset: -30 * time.Minute}, true},
+		{"на весь день в день перехода на летнее время", ical.Event{Summary: "Дача", Start: time.Date(2025, 3, 30, 0, 0, 0, 0, berlin), AllDay: true},
+			reminders.Reminder{Text: "Дача", Time: time.Date(2025, 3, 30, 9, 0, 0, 0, berlin)}, true},
+		{"неподдерживаемое правило", ical.Event{Summary: "Спорт", Start: start, RRule: "FREQ=WEEKLY;BYDAY=MO,WE"},
+			reminders.Reminder{Text: "Спорт", Time: start}, false},
+	}
+	for _, tt := range tests {
+		got, supported := eventReminder(tt.event, "untitled")
+		if got != tt.want || supported != tt.supported {
+			t.Errorf("%s: eventReminder = %+v, %v; ожидалось %+v, %v", tt.name, got, supported, tt.want, tt.supported)
+		}
+	}
+}
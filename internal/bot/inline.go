@@ -0,0 +1,206 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/services"
+)
+
+// inlineCacheTime — сколько секунд Telegram может держать ответ на inline-запрос:
+// курсы меняются раз в день, погода кэшируется на 10 минут
+const inlineCacheTime = 300
+
+// minPlaceLen — с какой длины названия искать место: inline-запросы приходят
+// на каждое нажатие клавиши, и по «М» или «Ми» искать город бессмысленно
+const minPlaceLen = 3
+
+// inlinePause — сколько ждать следующего нажатия, прежде чем запрашивать погоду
+// для найденного места: лимит OneCall не должен уходить на «мин», «минс»…
+const inlinePause = 700 * time.Millisecond
+
+// weatherWords — первое слово inline-запроса о погоде на всех языках интерфейса
+var weatherWords = map[string]bool{"погода": true, "weather": true, "надвор'е": true, "надворье": true}
+
+// inlineCurrencies — валюты, которые можно указать в inline-запросе
+var inlineCurrencies = map[string]services.CurrencyType{
+	"usd": services.USD, "eur": services.EUR, "rub": services.RUB, "byn": services.BYN,
+}
+
+// handleInlineQuery отвечает на inline-запросы в любом чате:
+// «@bot» — курсы и погода, «@bot usd» — курс, «@bot 100 eur» — пересчёт суммы,
+// «@bot погода Минск» — погода в городе. Язык, единицы и базовая валюта берутся
+// из личных настроек того, кто пишет запрос.
+func (app *BotApp) handleInlineQuery(c tele.Context) error {
+	p := app.prefsFor(c)
+	fields := strings.Fields(strings.ToLower(c.Query().Text))
+
+	var results tele.Results
+	cacheTime := inlineCacheTime
+	switch {
+	case len(fields) == 0:
+		results = app.inlineRates(p, services.USD, services.EUR, services.RUB)
+		if r, _ := app.inlineWeather(p, c.Sender().ID, "", ""); r != nil {
+			results = append(results, r)
+		}
+
+	case weatherWords[fields[0]]:
+		r, complete := app.inlineWeather(p, c.Sender().ID, c.Query().ID, strings.Join(fields[1:], " "))
+		if r != nil {
+			results = append(results, r)
+		}
+		if !complete {
+			// Карточка без погоды не должна задержаться в кэше Telegram
+			cacheTime = 0
+		}
+
+	default:
+		results = app.inlineCurrency(p, fields)
+	}
+
+	return c.Answer(&tele.QueryResponse{
+		Results:    results,
+		CacheTime:  cacheTime,
+		IsPersonal: true, // ответ зависит от настроек пользователя
+	})
+}
+
+// inlineRates строит карточки с курсами валют в базовой валюте пользователя
+func (app *BotApp) inlineRates(p chatPrefs, types ...services.CurrencyType) tele.Results {
+	var results tele.Results
+	for _, t := range rateTypes(types, p.currency) {
+		line, err := app.rateLine(p, t, p.currency)
+		if err != nil {
+			log.Printf("Ошибка при получении курса валют: %v", err)
+			continue
+		}
+		results = append(results, inlineArticle("rate-"+string(t), line, p.t("inline.rate_desc"), p.t("rates.line", line)))
+	}
+	return results
+}
+
+// inlineCurrency разбирает запрос о валюте: «usd», «100 eur», «100 eur usd»
+func (app *BotApp) inlineCurrency(p chatPrefs, fields []string) tele.Results {
+	amount, err := strconv.ParseFloat(strings.ReplaceAll(fields[0], ",", "."), 64)
+	if err != nil {
+		from, ok := inlineCurrencies[fields[0]]
+		if !ok || len(fields) > 1 {
+			return nil
+		}
+		return app.inlineRates(p, from)
+	}
+
+	if amount <= 0 || len(fields) < 2 || len(fields) > 3 {
+		return nil
+	}
+	from, ok := inlineCurrencies[fields[1]]
+	if !ok {
+		return nil
+	}
+	to := p.currency
+	if len(fields) == 3 {
+		if to, ok = inlineCurrencies[fields[2]]; !ok {
+			return nil
+		}
+	}
+
+	fromRate, err := app.currencySvc.GetRate(from)
+	if err != nil {
+		log.Printf("Ошибка при получении курса валют: %v", err)
+		return nil
+	}
+	toRate, err := app.currencySvc.GetRate(to)
+	if err != nil {
+		log.Printf("Ошибка при получении курса валют: %v", err)
+		return nil
+	}
+
+	line := fmt.Sprintf("%s = %s", p.money(amount, string(from)), p.money(amount*fromRate.PerUnit()/toRate.PerUnit(), string(to)))
+	return tele.Results{inlineArticle("convert", line, p.t("inline.rate_desc"), line)}
+}
+
+// inlineWeather строит карточку текущей погоды в городе query,
+// а без названия — в месте, сохранённом в личном чате пользователя.
+// Если погоды для найденного города нет в кэше, она запрашивается, только когда
+// пользователь перестал печатать; до тех пор карточка — лишь само место
+// и complete = false.
+func (app *BotApp) inlineWeather(p chatPrefs, userID int64, queryID, query string) (result tele.Result, complete bool) {
+	name := p.t("inline.my_place")
+	lat, lon := app.chatCoords(userID)
+
+	if query != "" {
+		if len([]rune(query)) < minPlaceLen {
+			return nil, true
+		}
+		place, err := app.weatherSvc.FindPlace(query, p.lang)
+		if err != nil {
+			if !errors.Is(err, services.ErrPlaceNotFound) {
+				log.Printf("Не удалось найти место %q: %v", query, err)
+			}
+			return nil, true
+		}
+		name, lat, lon = place.Name, place.Lat, place.Lon
+		if place.Country != "" {
+			name += ", " + place.Country
+		}
+	}
+
+	if query != "" && !app.weatherSvc.HasWeather(lat, lon, p.units, p.lang) && !app.pausedTyping(userID, queryID) {
+		return placeResult(name, lat, lon), false
+	}
+
+	res, err := app.weatherAt(lat, lon, p)
+	if err != nil {
+		log.Printf("Ошибка при получении погоды: %v", err)
+		return nil, true
+	}
+
+	cur := res.Current
+	desc := p.t("weather.nodata")
+	if len(cur.Weather) > 0 {
+		desc = cur.Weather[0].Description
+	}
+
+	return inlineArticle("weather-"+lat+","+lon,
+		p.t("inline.weather_title", name),
+		p.temp(cur.Temp)+", "+desc,
+		p.t("inline.weather_title", name)+"\n\n"+app.formatCurrentWeather(cur, p),
+	), true
+}
+
+// pausedTyping ждёт inlinePause и сообщает, остался ли запрос queryID последним
+// inline-запросом пользователя, то есть перестал ли он печатать. Ждёт только при
+// long polling: с вебхуком обработчики синхронны, и пауза задержала бы ответ
+// Telegram на каждое нажатие, а экземпляры функции не видят запросов друг друга.
+// Там от лишних запросов погоды защищают minPlaceLen и кэш WeatherService.
+func (app *BotApp) pausedTyping(userID int64, queryID string) bool {
+	if !app.polling.Load() {
+		return true
+	}
+
+	app.inlineQueries.Store(userID, queryID)
+	time.Sleep(inlinePause)
+	return app.inlineQueries.CompareAndDelete(userID, queryID)
+}
+
+// placeResult — карточка найденного места без погоды: отправляет его точку на карте
+func placeResult(name, lat, lon string) tele.Result {
+	latF, _ := strconv.ParseFloat(lat, 32)
+	lonF, _ := strconv.ParseFloat(lon, 32)
+	result := &tele.LocationResult{Location: tele.Location{Lat: float32(latF), Lng: float32(lonF)}, Title: "📍 " + name}
+	result.SetResultID("place-" + lat + "," + lon)
+	return result
+}
+
+// inlineArticle создаёт карточку inline-ответа, которая отправляет текст text
+func inlineArticle(id, title, description, text string) *tele.ArticleResult {
+	article := &tele.ArticleResult{Title: title, Description: description, Text: text}
+	article.SetResultID(id)
+	return article
+}
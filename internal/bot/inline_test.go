@@ -0,0 +1,38 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPausedTypingOnlyForLastQuery(t *testing.T) {
+	app := &BotApp{}
+	app.polling.Store(true)
+
+	first := make(chan bool)
+	go func() { first <- app.pausedTyping(1, "q1") }()
+	time.Sleep(inlinePause / 4)
+
+	// Следующее нажатие до конца паузы отменяет запрос погоды для предыдущего
+	if !app.pausedTyping(1, "q2") {
+		t.Error("последний запрос не дождался паузы")
+	}
+	if <-first {
+		t.Error("запрос, за которым последовало нажатие, тоже запросил погоду")
+	}
+	if !app.pausedTyping(2, "other") {
+		t.Error("запросы разных пользователей мешают друг другу")
+	}
+}
+
+func TestPausedTypingWithoutPolling(t *testing.T) {
+	app := &BotApp{}
+
+	start := time.Now()
+	if !app.pausedTyping(1, "q1") || !app.pausedTyping(1, "q2") {
+		t.Error("с вебхуком запрос погоды отменён")
+	}
+	if elapsed := time.Since(start); elapsed >= inlinePause {
+		t.Errorf("с вебхуком обработчик ждал паузу: %v", elapsed)
+	}
+}
@@ -0,0 +1,91 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Shutdown останавливает приём апдейтов и фоновые задачи, дожидается
+// отправки текущей пачки напоминаний и выполняющихся обработчиков и сбрасывает хранилища.
+// Фоновые задачи должны быть остановлены отменой контекста, переданного в
+// StartReminderChecker; ctx ограничивает время ожидания. Хранилища закрываются
+// в любом случае, даже если дождаться чего-то не удалось: иначе несохранённые
+// данные пропадут.
+func (app *BotApp) Shutdown(ctx context.Context) error {
+	var errs []error
+
+	// 1. Перестаём принимать апдейты
+	if app.polling.CompareAndSwap(true, false) {
+		if err := waitCtx(ctx, app.bot.Stop); err != nil {
+			errs = append(errs, fmt.Errorf("не дождались остановки поллера: %w", err))
+		}
+	}
+
+	// 2. Останавливаем cron и ждём уже запущенные задачи
+	if app.cron != nil {
+		select {
+		case <-app.cron.Stop().Done():
+		case <-ctx.Done():
+			errs = append(errs, fmt.Errorf("не дождались завершения cron-задач: %w", ctx.Err()))
+		}
+	}
+
+	// 3. Ждём, пока проверка напоминаний дошлёт текущую пачку,
+	// а обработчики апдейтов допишут свои изменения
+	if err := waitCtx(ctx, app.background.Wait); err != nil {
+		errs = append(errs, fmt.Errorf("не дождались фоновых задач: %w", err))
+	}
+	if err := waitCtx(ctx, app.handlers.Wait); err != nil {
+		errs = append(errs, fmt.Errorf("не дождались обработчиков апдейтов: %w", err))
+	}
+
+	// 4. Сбрасываем хранилища
+	if err := app.storage.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("хранилище напоминаний: %w", err))
+	}
+	if err := app.geo.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("хранилище напоминаний по месту: %w", err))
+	}
+	if err := app.expenses.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("хранилище трат: %w", err))
+	}
+	if err := app.conversations.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("хранилище диалогов: %w", err))
+	}
+	if err := app.settings.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("хранилище настроек: %w", err))
+	}
+
+	log.Println("Бот остановлен")
+	return errors.Join(errs...)
+}
+
+// trackHandlers учитывает выполняющиеся обработчики: telebot запускает их
+// в отдельных горутинах, и Shutdown должен дождаться их до закрытия хранилищ
+func (app *BotApp) trackHandlers(next tele.HandlerFunc) tele.HandlerFunc {
+	return func(c tele.Context) error {
+		app.handlers.Add(1)
+		defer app.handlers.Done()
+		return next(c)
+	}
+}
+
+// waitCtx выполняет блокирующую функцию, но ждёт её не дольше, чем живёт ctx
+func waitCtx(ctx context.Context, fn func()) error {
+	done := make(chan struct{})
+	go func() {
+		fn()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,58 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/conversation"
+	"tg-bot/internal/expenses"
+	"tg-bot/internal/reminders"
+	"tg-bot/internal/settings"
+)
+
+// closeSpy запоминает, что хранилище закрыли
+type closeSpy struct {
+	settings.Storage
+	closed bool
+}
+
+func (s *closeSpy) Close() error {
+	s.closed = true
+	return s.Storage.Close()
+}
+
+func TestShutdownClosesStoragesOnTimeout(t *testing.T) {
+	spy := &closeSpy{Storage: settings.NewMemoryStorage()}
+	app := &BotApp{
+		storage:       reminders.NewMemoryStorage(),
+		geo:           reminders.NewMemoryGeoStorage(),
+		expenses:      expenses.NewMemoryStorage(),
+		conversations: conversation.NewMemoryStorage(),
+		settings:      spy,
+	}
+
+	// Обработчик, который не успевает завершиться
+	started, stuck := make(chan struct{}), make(chan struct{})
+	defer close(stuck)
+	go app.trackHandlers(func(c tele.Context) error {
+		close(started)
+		<-stuck
+		return nil
+	})(nil)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := app.Shutdown(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown вернул %v, ожидалась ошибка таймаута", err)
+	}
+	if !spy.closed {
+		t.Error("хранилище не закрыто после таймаута")
+	}
+}
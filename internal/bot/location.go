@@ -0,0 +1,55 @@
+package bot
+
+import (
+	"log"
+	"strconv"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/settings"
+)
+
+// Координаты по умолчанию — для тех, кто ещё не поделился местоположением
+const (
+	defaultLat = "55.139235"
+	defaultLon = "27.6845787"
+)
+
+// chatCoords возвращает координаты, сохранённые для чата, или координаты по умолчанию
+func (app *BotApp) chatCoords(chatID int64) (string, string) {
+	s, err := app.settings.Get(chatID)
+	if err != nil {
+		log.Printf("Не удалось получить настройки чата %d: %v", chatID, err)
+		return defaultLat, defaultLon
+	}
+	if s.Location == nil {
+		return defaultLat, defaultLon
+	}
+
+	return strconv.FormatFloat(s.Location.Lat, 'f', 6, 64), strconv.FormatFloat(s.Location.Lon, 'f', 6, 64)
+}
+
+// handleLocation сохраняет присланное пользователем местоположение.
+// Трансляция геопозиции не меняет сохранённое место, а только проверяет напоминания по месту.
+func (app *BotApp) handleLocation(c tele.Context) error {
+	loc := c.Message().Location
+	if loc == nil {
+		return nil
+	}
+
+	if loc.LivePeriod > 0 {
+		return app.checkGeoReminders(c, c.Sender().ID, loc)
+	}
+	if !app.isChatAdmin(c) {
+		return c.Reply(app.prefsFor(c).t("group.admins_only"))
+	}
+
+	err := app.updateSettings(c.Chat().ID, func(s *settings.Settings) {
+		s.Location = &settings.Location{Lat: float64(loc.Lat), Lon: float64(loc.Lng)}
+	})
+	if err != nil {
+		return c.Send(app.prefsFor(c).t("location.save_failed"))
+	}
+
+	return c.Send(app.prefsFor(c).t("location.saved"))
+}
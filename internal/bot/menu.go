@@ -0,0 +1,248 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/format"
+	"tg-bot/internal/i18n"
+	"tg-bot/internal/services"
+	"tg-bot/internal/settings"
+)
+
+// menuItem — кнопка меню: открывает подменю, выполняет действие
+// или (request) просит у пользователя геопозицию
+type menuItem struct {
+	text    string           // ключ сообщения; коды валют выводятся как есть
+	submenu string           // имя меню, которое открывает кнопка
+	action  tele.HandlerFunc // действие кнопки, если это не подменю
+	request bool             // кнопка запроса геопозиции; её обрабатывает tele.OnLocation
+	admin   bool             // меняет настройки чата: в группах доступна только администраторам
+}
+
+// menu — экран с reply-клавиатурой. Клавиатура строится заново на каждый ответ,
+// поэтому одновременные пользователи не мешают друг другу.
+type menu struct {
+	title  string // ключ текста сообщения, с которым показывается меню
+	parent string // имя родительского меню; пусто — это главное меню
+	rows   [][]menuItem
+}
+
+// mainMenu — имя главного меню
+const mainMenu = "main"
+
+// settingsBtn — inline-кнопка перехода к настройкам из приветствия
+var settingsBtn = tele.Btn{Unique: "setting"}
+
+// menus описывает все меню бота: из этих же определений строятся клавиатуры
+// и регистрируются обработчики кнопок
+func (app *BotApp) menus() map[string]menu {
+	return map[string]menu{
+		mainMenu: {
+			title: "menu.main",
+			rows: [][]menuItem{
+				{{text: "menu.weather", submenu: "weather"}, {text: "menu.expenses", action: app.handleExpenses}, {text: "menu.currency", submenu: "currency"}},
+				{{text: "menu.settings", submenu: "settings"}},
+			},
+		},
+		"weather": {
+			title:  "menu.weather_title",
+			parent: mainMenu,
+			rows: [][]menuItem{
+				{{text: "menu.weather_today", action: app.handleTodayWeather}, {text: "menu.weather_now", action: app.handleCurrentWeather}},
+				{{text: "menu.air", action: app.handleAir}, {text: "menu.sun", action: app.handleSunMoon}},
+			},
+		},
+		"currency": {
+			title:  "menu.currency_title",
+			parent: mainMenu,
+			rows: [][]menuItem{
+				{{text: "USD", action: app.rates(services.USD)}, {text: "RU", action: app.rates(services.RUB)}, {text: "EUR", action: app.rates(services.EUR)}},
+				{{text: "USD, RU, EUR", action: app.rates(services.EUR, services.USD, services.RUB)}},
+			},
+		},
+		"settings": {
+			title:  "menu.settings_title",
+			parent: mainMenu,
+			rows: [][]menuItem{
+				{{text: "menu.send_location", request: true}},
+				{{text: "menu.brief", action: app.toggleBrief, admin: true}, {text: "menu.advice", action: app.handleAdvice, admin: true}},
+				{{text: "menu.calendar", action: app.handleCalDAV}, {text: "menu.all_settings", action: app.handleSettings, admin: true}},
+			},
+		},
+	}
+}
+
+// markup строит новую клавиатуру меню на языке lang с кнопками навигации.
+// Запрос геопозиции Telegram разрешает только в личных чатах.
+func (m menu) markup(all map[string]menu, lang string, private bool) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{ResizeKeyboard: true}
+
+	rows := make([]tele.Row, 0, len(m.rows)+1)
+	for _, items := range m.rows {
+		row := make(tele.Row, 0, len(items))
+		for _, item := range items {
+			switch {
+			case item.request && !private:
+				continue
+			case item.request:
+				row = append(row, rm.Location(i18n.T(lang, item.text)))
+			default:
+				row = append(row, rm.Text(i18n.T(lang, item.text)))
+			}
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+	}
+
+	switch {
+	case m.parent == "":
+	case m.parent == mainMenu:
+		rows = append(rows, rm.Row(rm.Text(i18n.T(lang, "menu.home"))))
+	default:
+		rows = append(rows, rm.Row(rm.Text(backText(lang, all[m.parent])), rm.Text(i18n.T(lang, "menu.home"))))
+	}
+
+	rm.Reply(rows...)
+	return rm
+}
+
+// backText — текст кнопки возврата в меню parent: «⬅️ Назад: Выберите промежуток»
+func backText(lang string, parent menu) string {
+	return i18n.T(lang, "menu.back") + i18n.T(lang, parent.title)
+}
+
+// registerMenus регистрирует обработчики всех кнопок из определений меню на всех языках.
+// Кнопка определяется только текстом, поэтому тексты одного языка должны быть уникальны.
+func (app *BotApp) registerMenus() {
+	all := app.menus()
+	app.menuDefs = all
+	app.menuButtons = make(map[string]bool)
+
+	for _, lang := range i18n.Languages {
+		seen := make(map[string]bool)
+		handle := func(key string, h tele.HandlerFunc) {
+			text := i18n.T(lang, key)
+			if seen[text] {
+				log.Printf("Кнопка %q (%s) встречается в меню несколько раз, обработчик заменён", text, lang)
+			}
+			seen[text] = true
+			app.menuButtons[text] = true
+			app.bot.Handle(&tele.Btn{Text: text}, h)
+		}
+
+		for _, m := range all {
+			for _, items := range m.rows {
+				for _, item := range items {
+					switch {
+					case item.submenu != "":
+						handle(item.text, app.showMenu(item.submenu))
+					case item.action != nil && item.admin:
+						handle(item.text, app.adminOnly(item.action))
+					case item.action != nil:
+						handle(item.text, item.action)
+					}
+				}
+			}
+			if m.parent != "" && m.parent != mainMenu {
+				handle(backText(lang, all[m.parent]), app.showMenu(m.parent))
+			}
+		}
+		handle("menu.home", app.showMenu(mainMenu))
+	}
+}
+
+// showMenu возвращает обработчик, который показывает меню name
+func (app *BotApp) showMenu(name string) tele.HandlerFunc {
+	return func(c tele.Context) error {
+		p := app.prefsFor(c)
+		return c.Send(p.t(app.menuDefs[name].title), app.menuMarkup(c, p, name))
+	}
+}
+
+// menuMarkup строит клавиатуру меню name для текущего чата
+func (app *BotApp) menuMarkup(c tele.Context, p chatPrefs, name string) *tele.ReplyMarkup {
+	return app.menuDefs[name].markup(app.menuDefs, p.lang, c.Chat().Type == tele.ChatPrivate)
+}
+
+// rates возвращает действие, которое показывает курсы выбранных валют в базовой валюте чата
+func (app *BotApp) rates(types ...services.CurrencyType) tele.HandlerFunc {
+	return func(c tele.Context) error {
+		p := app.prefsFor(c)
+		base := p.currency
+		lines := make([]string, 0, len(types))
+		for _, t := range rateTypes(types, base) {
+			line, err := app.rateLine(p, t, base)
+			if err != nil {
+				log.Printf("Ошибка при получении курса валют: %v", err)
+				return c.Send(p.t("rates.failed"))
+			}
+			lines = append(lines, p.t("rates.line", line))
+		}
+		return c.Send(strings.Join(lines, "\n"))
+	}
+}
+
+// rateTypes заменяет базовую валюту в списке на белорусский рубль:
+// курс валюты к самой себе не нужен, а курс BYN при другой базе — нужен
+func rateTypes(types []services.CurrencyType, base services.CurrencyType) []services.CurrencyType {
+	out := make([]services.CurrencyType, 0, len(types))
+	for _, t := range types {
+		if t == base {
+			if base == services.BYN {
+				continue
+			}
+			t = services.BYN
+		}
+		if !slices.Contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// rateLine пересчитывает официальный курс Нацбанка в базовую валюту:
+// «USD: 3,2456 BYN», «100 RUB: 1,2 USD». Курс указывается за столько же единиц, что и у Нацбанка.
+func (app *BotApp) rateLine(p chatPrefs, t, base services.CurrencyType) (string, error) {
+	rate, err := app.currencySvc.GetRate(t)
+	if err != nil {
+		return "", err
+	}
+	baseRate, err := app.currencySvc.GetRate(base)
+	if err != nil {
+		return "", err
+	}
+
+	scale := max(rate.Scale, 1)
+	value := rate.PerUnit() * float64(scale) / baseRate.PerUnit()
+	amount := format.Compact(p.lang, value, 4)
+
+	if scale > 1 {
+		return fmt.Sprintf("%d %s: %s %s", scale, t, amount, base), nil
+	}
+	return fmt.Sprintf("%s: %s %s", t, amount, base), nil
+}
+
+// toggleBrief включает или выключает утреннюю сводку
+func (app *BotApp) toggleBrief(c tele.Context) error {
+	p := app.prefsFor(c)
+	var on bool
+	var at string
+	err := app.updateSettings(c.Chat().ID, func(s *settings.Settings) {
+		s.Brief = !s.Brief
+		on, at = s.Brief, s.BriefAt()
+	})
+	if err != nil {
+		return c.Send(p.t("settings.save_failed"))
+	}
+
+	if on {
+		return c.Send(p.t("brief.on", at))
+	}
+	return c.Send(p.t("brief.off"))
+}
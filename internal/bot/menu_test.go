@@ -0,0 +1,151 @@
+package bot
+
+import (
+	"slices"
+	"testing"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/i18n"
+)
+
+// keyboardTexts возвращает тексты кнопок reply-клавиатуры по рядам
+func keyboardTexts(rm tele.ReplyMarkup) [][]string {
+	rows := make([][]string, 0, len(rm.ReplyKeyboard))
+	for _, row := range rm.ReplyKeyboard {
+		var texts []string
+		for _, btn := range row {
+			texts = append(texts, btn.Text)
+		}
+		rows = append(rows, texts)
+	}
+	return rows
+}
+
+// lastMenu возвращает текст и кнопки последнего отправленного сообщения
+func lastMenu(t *testing.T, tg *fakeTelegram) (string, [][]string) {
+	t.Helper()
+	list := tg.sent("sendMessage")
+	if len(list) == 0 {
+		t.Fatal("бот ничего не ответил")
+	}
+	last := list[len(list)-1]
+	return last.Text(), keyboardTexts(last.Markup())
+}
+
+func TestMenuNavigation(t *testing.T) {
+	app, tg := newTestBot(t)
+	tr := func(key string) string { return i18n.T("ru", key) }
+
+	send(app, privateChat, testUser, "/start")
+	text, rows := lastMenu(t, tg)
+	if text != tr("menu.main") {
+		t.Fatalf("/start показал %q", text)
+	}
+	want := [][]string{
+		{tr("menu.weather"), tr("menu.expenses"), tr("menu.currency")},
+		{tr("menu.settings")},
+	}
+	if !slices.EqualFunc(rows, want, slices.Equal) {
+		t.Fatalf("главное меню %q, ожидалось %q", rows, want)
+	}
+
+	tg.reset()
+	send(app, privateChat, testUser, tr("menu.weather"))
+	text, rows = lastMenu(t, tg)
+	if text != tr("menu.weather_title") {
+		t.Fatalf("кнопка погоды показала %q", text)
+	}
+	if last := rows[len(rows)-1]; !slices.Equal(last, []string{tr("menu.home")}) {
+		t.Errorf("в подменю нет кнопки главного меню: %q", rows)
+	}
+
+	tg.reset()
+	send(app, privateChat, testUser, tr("menu.home"))
+	if text, _ := lastMenu(t, tg); text != tr("menu.main") {
+		t.Errorf("кнопка главного меню показала %q", text)
+	}
+}
+
+func TestMenuLocationButton(t *testing.T) {
+	app, tg := newTestBot(t)
+	tr := func(key string) string { return i18n.T("ru", key) }
+
+	// Запросить геопозицию кнопкой Telegram разрешает только в личных чатах
+	send(app, privateChat, testUser, tr("menu.settings"))
+	list := tg.sent("sendMessage")
+	rm := list[len(list)-1].Markup()
+	if len(rm.ReplyKeyboard) == 0 || !rm.ReplyKeyboard[0][0].Location {
+		t.Fatalf("в личном чате нет кнопки геопозиции: %+v", rm.ReplyKeyboard)
+	}
+
+	tg.reset()
+	send(app, testGroup, testUser, tr("menu.settings"))
+	_, rows := lastMenu(t, tg)
+	for _, row := range rows {
+		if slices.Contains(row, tr("menu.send_location")) {
+			t.Fatalf("в группе показана кнопка геопозиции: %q", rows)
+		}
+	}
+}
+
+func TestMenuLanguage(t *testing.T) {
+	app, tg := newTestBot(t)
+	english := &tele.User{ID: 300, FirstName: "Kate", LanguageCode: "en-US"}
+	chat := &tele.Chat{ID: english.ID, Type: tele.ChatPrivate}
+
+	// Клавиатура строится на каждый ответ: соседние чаты получают каждый свой язык
+	send(app, chat, english, "/start")
+	send(app, privateChat, testUser, "/start")
+	send(app, chat, english, i18n.T("en", "menu.currency"))
+
+	var titles []string
+	for _, c := range tg.sent("sendMessage") {
+		if rows := keyboardTexts(c.Markup()); len(rows) > 0 {
+			titles = append(titles, c.Text()+" "+rows[0][0])
+		}
+	}
+	want := []string{
+		i18n.T("en", "menu.main") + " " + i18n.T("en", "menu.weather"),
+		i18n.T("ru", "menu.main") + " " + i18n.T("ru", "menu.weather"),
+		i18n.T("en", "menu.currency_title") + " USD",
+	}
+	if !slices.Equal(titles, want) {
+		t.Errorf("меню %q, ожидалось %q", titles, want)
+	}
+}
+
+// TestMenuTextsUnique проверяет, что кнопки одного языка различаются текстом:
+// обработчик кнопки находится только по нему
+func TestMenuTextsUnique(t *testing.T) {
+	app, _ := newTestBot(t)
+	all := app.menus()
+
+	for _, lang := range i18n.Languages {
+		seen := make(map[string]string)
+		check := func(text, where string) {
+			if prev, ok := seen[text]; ok {
+				t.Errorf("%s: кнопка %q есть в меню %s и %s", lang, text, prev, where)
+			}
+			seen[text] = where
+		}
+
+		for name, m := range all {
+			if m.parent != "" && all[m.parent].rows == nil {
+				t.Errorf("у меню %s нет родителя %s", name, m.parent)
+			}
+			for _, items := range m.rows {
+				for _, item := range items {
+					if item.submenu != "" && all[item.submenu].rows == nil {
+						t.Errorf("кнопка %s открывает несуществующее меню %s", item.text, item.submenu)
+					}
+					check(i18n.T(lang, item.text), name)
+				}
+			}
+			if m.parent != "" && m.parent != mainMenu {
+				check(backText(lang, all[m.parent]), name)
+			}
+		}
+		check(i18n.T(lang, "menu.home"), "навигации")
+	}
+}
@@ -0,0 +1,264 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/i18n"
+)
+
+// Инлайн-виджеты выбора даты и времени. Всё состояние виджета — в данных
+// кнопок (шаг диалога, допустимый диапазон, показанный месяц или выбранный
+// день), поэтому виджет работает без хранения и в serverless-режиме.
+// Выбор передаётся текущему шагу диалога как обычный текстовый ответ.
+
+// pickerRange — какие дни можно выбрать
+type pickerRange string
+
+const (
+	pickFuture pickerRange = "f" // сегодня и позже — для напоминаний
+	pickPast   pickerRange = "p" // сегодня и раньше — для трат
+)
+
+const (
+	pickerMonths = 24 // на сколько месяцев вперёд или назад можно листать
+	minuteStep   = 5  // шаг минут в выборе времени
+)
+
+var (
+	dateBtn  = tele.Btn{Unique: "date"}
+	clockBtn = tele.Btn{Unique: "clock"}
+)
+
+// pickerBtn — кнопка виджета с данными через «|»
+func pickerBtn(rm *tele.ReplyMarkup, kind tele.Btn, text string, args ...string) tele.Btn {
+	return rm.Data(text, kind.Unique, args...)
+}
+
+// noopBtn — кнопка-подпись, нажатие которой ничего не делает
+func noopBtn(rm *tele.ReplyMarkup, text string) tele.Btn {
+	return pickerBtn(rm, dateBtn, text, "-")
+}
+
+// datePicker строит сетку месяца month для шага диалога step
+func (app *BotApp) datePicker(p chatPrefs, step string, rng pickerRange, month time.Time) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{}
+	loc := month.Location() // часовой пояс чата приходит вместе с месяцем
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, loc)
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+
+	// Заголовок: листание и название месяца
+	prev, next := first.AddDate(0, -1, 0), first.AddDate(0, 1, 0)
+	nav := func(to time.Time, text string) tele.Btn {
+		if !monthAllowed(to, current, rng) {
+			return noopBtn(rm, " ")
+		}
+		return pickerBtn(rm, dateBtn, text, "m", step, string(rng), to.Format("2006-01"))
+	}
+	title := fmt.Sprintf("%s %d", i18n.MonthTitle(p.lang, first), first.Year())
+	rows := []tele.Row{{nav(prev, "‹"), noopBtn(rm, title), nav(next, "›")}}
+
+	// Дни недели, начиная с понедельника
+	var week tele.Row
+	for d := 0; d < 7; d++ {
+		week = append(week, noopBtn(rm, i18n.ShortDayName(p.lang, time.Date(2024, 1, 1+d, 0, 0, 0, 0, time.UTC))))
+	}
+	rows = append(rows, week)
+
+	// Сетка дней: пустые клетки до первого числа и после последнего
+	week = nil
+	for i := 0; i < (int(first.Weekday())+6)%7; i++ {
+		week = append(week, noopBtn(rm, " "))
+	}
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		label := strconv.Itoa(day.Day())
+		switch {
+		case (rng == pickFuture && day.Before(today)) || (rng == pickPast && day.After(today)):
+			week = append(week, noopBtn(rm, "·"))
+		default:
+			if day.Equal(today) {
+				label = "(" + label + ")"
+			}
+			week = append(week, pickerBtn(rm, dateBtn, label, "d", step, string(rng), day.Format("2006-01-02")))
+		}
+		if len(week) == 7 {
+			rows = append(rows, week)
+			week = nil
+		}
+	}
+	if len(week) > 0 {
+		for len(week) < 7 {
+			week = append(week, noopBtn(rm, " "))
+		}
+		rows = append(rows, week)
+	}
+
+	rm.Inline(rows...)
+	return rm
+}
+
+// monthAllowed ограничивает листание: не в прошлое для будущих дат и наоборот
+func monthAllowed(month, current time.Time, rng pickerRange) bool {
+	if rng == pickFuture {
+		return !month.Before(current) && month.Before(current.AddDate(0, pickerMonths+1, 0))
+	}
+	return !month.After(current) && month.After(current.AddDate(0, -pickerMonths-1, 0))
+}
+
+// timePicker строит выбор часа для дня date; для сегодняшнего дня прошедшие часы недоступны
+func (app *BotApp) timePicker(step string, date time.Time) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{}
+	now := time.Now().In(date.Location())
+	day := date.Format("2006-01-02")
+
+	var rows []tele.Row
+	var row tele.Row
+	for h := 0; h < 24; h++ {
+		label := fmt.Sprintf("%02d", h)
+		if time.Date(date.Year(), date.Month(), date.Day(), h, 60-minuteStep, 0, 0, date.Location()).Before(now) {
+			row = append(row, noopBtn(rm, "·"))
+		} else {
+			row = append(row, pickerBtn(rm, clockBtn, label, "h", step, day, strconv.Itoa(h)))
+		}
+		if len(row) == 6 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+
+	rm.Inline(rows...)
+	return rm
+}
+
+// minutePicker строит выбор минут для часа hour дня date
+func (app *BotApp) minutePicker(p chatPrefs, step string, date time.Time, hour int) *tele.ReplyMarkup {
+	rm := &tele.ReplyMarkup{}
+	now := time.Now().In(date.Location())
+	day := date.Format("2006-01-02")
+
+	var rows []tele.Row
+	var row tele.Row
+	for m := 0; m < 60; m += minuteStep {
+		clock := fmt.Sprintf("%02d:%02d", hour, m)
+		if time.Date(date.Year(), date.Month(), date.Day(), hour, m, 0, 0, date.Location()).Before(now) {
+			row = append(row, noopBtn(rm, "·"))
+		} else {
+			row = append(row, pickerBtn(rm, clockBtn, clock, "t", step, day, clock))
+		}
+		if len(row) == 4 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	rows = append(rows, tele.Row{pickerBtn(rm, clockBtn, p.t("picker.other_hour"), "b", step, day)})
+
+	rm.Inline(rows...)
+	return rm
+}
+
+// handleDatePicker обрабатывает нажатия в календаре: листание и выбор дня
+func (app *BotApp) handleDatePicker(c tele.Context) error {
+	args := c.Args()
+	if len(args) < 4 {
+		return c.Respond()
+	}
+	action, step, rng, value := args[0], args[1], pickerRange(args[2]), args[3]
+	p := app.prefsFor(c)
+
+	switch action {
+	case "m":
+		month, err := time.ParseInLocation("2006-01", value, p.loc)
+		if err != nil {
+			return c.Respond()
+		}
+		if _, err := app.bot.EditReplyMarkup(c.Callback().Message, app.datePicker(p, step, rng, month)); err != nil {
+			return err
+		}
+		return c.Respond()
+
+	case "d":
+		day, err := time.ParseInLocation("2006-01-02", value, p.loc)
+		if err != nil {
+			return c.Respond()
+		}
+		return app.pickerAnswer(c, step, value, "📅 "+p.date(day))
+	}
+	return c.Respond()
+}
+
+// handleTimePicker обрабатывает нажатия в выборе времени: час, минуты, возврат к часам
+func (app *BotApp) handleTimePicker(c tele.Context) error {
+	args := c.Args()
+	if len(args) < 3 {
+		return c.Respond()
+	}
+	action, step := args[0], args[1]
+	p := app.prefsFor(c)
+	day, err := time.ParseInLocation("2006-01-02", args[2], p.loc)
+	if err != nil {
+		return c.Respond()
+	}
+
+	var markup *tele.ReplyMarkup
+	switch {
+	case action == "h" && len(args) == 4:
+		hour, err := strconv.Atoi(args[3])
+		if err != nil || hour < 0 || hour > 23 {
+			return c.Respond()
+		}
+		markup = app.minutePicker(p, step, day, hour)
+	case action == "b":
+		markup = app.timePicker(step, day)
+	case action == "t" && len(args) == 4:
+		return app.pickerAnswer(c, step, args[3], "🕒 "+args[3])
+	default:
+		return c.Respond()
+	}
+
+	if _, err := app.bot.EditReplyMarkup(c.Callback().Message, markup); err != nil {
+		return err
+	}
+	return c.Respond()
+}
+
+// pickerOwner возвращает ID участника, для которого показан виджет: в группе вопрос
+// шага — ответ на его сообщение (см. prompt). 0 — владелец не известен (личный чат).
+func pickerOwner(widget *tele.Message) int64 {
+	if widget == nil || widget.ReplyTo == nil || widget.ReplyTo.Sender == nil {
+		return 0
+	}
+	return widget.ReplyTo.Sender.ID
+}
+
+// pickerAnswer передаёт выбор шагу диалога и заменяет виджет текстом выбора
+func (app *BotApp) pickerAnswer(c tele.Context, step, answer, chosen string) error {
+	p := app.prefsFor(c)
+	owner := pickerOwner(c.Callback().Message)
+	if owner != 0 && owner != c.Sender().ID {
+		return c.Respond(&tele.CallbackResponse{Text: p.t("picker.not_yours")})
+	}
+
+	st, ok, err := app.dialog(c)
+	switch {
+	case err != nil:
+		return c.Respond(&tele.CallbackResponse{Text: p.t("picker.no_dialog")})
+	case !ok && owner == 0 && c.Chat().Type != tele.ChatPrivate:
+		// Виджет без владельца в группе может принадлежать другому участнику — не убираем его
+		return c.Respond(&tele.CallbackResponse{Text: p.t("picker.stale")})
+	case !ok || st.Step != step:
+		c.Respond(&tele.CallbackResponse{Text: p.t("picker.stale")})
+		_, err := app.bot.EditReplyMarkup(c.Callback().Message, nil)
+		return err
+	}
+
+	c.Respond()
+	if err := c.Edit(c.Message().Text + "\n" + chosen); err != nil {
+		return err
+	}
+	return app.advanceFlow(c, st, answer)
+}
@@ -0,0 +1,177 @@
+package bot
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/i18n"
+	"tg-bot/internal/settings"
+)
+
+// callbackData возвращает данные всех инлайн-кнопок в виде «unique|data».
+// До отправки unique лежит в кнопке отдельно, в отправленной — в данных после «\f».
+func callbackData(rm tele.ReplyMarkup) []string {
+	var list []string
+	for _, row := range rm.InlineKeyboard {
+		for _, btn := range row {
+			data, sent := strings.CutPrefix(btn.Data, "\f")
+			if !sent {
+				data = btn.Unique + "|" + data
+			}
+			list = append(list, data)
+		}
+	}
+	return list
+}
+
+// lastMarkup возвращает клавиатуру последнего запроса с методом method
+func lastMarkup(t *testing.T, tg *fakeTelegram, method string) tele.ReplyMarkup {
+	t.Helper()
+	list := tg.sent(method)
+	if len(list) == 0 {
+		t.Fatalf("бот не вызвал %s", method)
+	}
+	return list[len(list)-1].Markup()
+}
+
+func TestRemindFlowWithPickers(t *testing.T) {
+	app, tg := newTestBot(t)
+	now := time.Now().In(testZone)
+	tomorrow := now.AddDate(0, 0, 1)
+	day := tomorrow.Format("2006-01-02")
+
+	send(app, privateChat, testUser, "/remind")
+	if data := callbackData(lastMarkup(t, tg, "sendMessage")); !slices.Contains(data, "date|d|date|f|"+now.Format("2006-01-02")) {
+		t.Fatalf("в календаре нет сегодняшнего дня: %q", data)
+	}
+
+	tg.reset()
+	press(app, privateChat, testUser, dateBtn, "d|date|f|"+day)
+	if edits := tg.sent("editMessageText"); len(edits) != 1 || !strings.Contains(edits[0].Text(), "📅") {
+		t.Fatalf("календарь не заменён выбором: %+v", edits)
+	}
+	if data := callbackData(lastMarkup(t, tg, "sendMessage")); !slices.Contains(data, "clock|h|time|"+day+"|9") {
+		t.Fatalf("нет выбора часа: %q", data)
+	}
+
+	tg.reset()
+	press(app, privateChat, testUser, clockBtn, "h|time|"+day+"|9")
+	data := callbackData(lastMarkup(t, tg, "editMessageReplyMarkup"))
+	if !slices.Contains(data, "clock|t|time|"+day+"|09:30") || !slices.Contains(data, "clock|b|time|"+day) {
+		t.Fatalf("нет выбора минут: %q", data)
+	}
+
+	tg.reset()
+	press(app, privateChat, testUser, clockBtn, "t|time|"+day+"|09:30")
+	if st, ok, _ := app.conversations.Get(privateChat.ID, testUser.ID); !ok || st.Step != "text" {
+		t.Fatalf("после выбора времени диалог %+v, %v", st, ok)
+	}
+
+	send(app, privateChat, testUser, "полить цветы")
+	list := app.storage.ListAll()
+	want := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 9, 30, 0, 0, testZone)
+	if len(list) != 1 || !list[0].Time.Equal(want) || list[0].Text != "полить цветы" {
+		t.Fatalf("напоминания %+v, ожидалось на %v", list, want)
+	}
+}
+
+func TestDatePickerRange(t *testing.T) {
+	app, _ := newTestBot(t)
+	p := prefsOf(settings.Settings{ChatID: privateChat.ID}, testZone)
+	now := time.Now().In(testZone)
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, testZone)
+	today, yesterday, tomorrow := now.Format("2006-01-02"), now.AddDate(0, 0, -1).Format("2006-01-02"), now.AddDate(0, 0, 1).Format("2006-01-02")
+	prev, next := month.AddDate(0, -1, 0).Format("2006-01"), month.AddDate(0, 1, 0).Format("2006-01")
+
+	tests := []struct {
+		rng     pickerRange
+		allowed []string
+		denied  []string
+	}{
+		{pickFuture, []string{"d|s|f|" + today, "m|s|f|" + next}, []string{"d|s|f|" + yesterday, "m|s|f|" + prev}},
+		{pickPast, []string{"d|s|p|" + today, "m|s|p|" + prev}, []string{"d|s|p|" + tomorrow, "m|s|p|" + next}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.rng), func(t *testing.T) {
+			data := callbackData(*app.datePicker(p, "s", tt.rng, now))
+			for _, d := range tt.allowed {
+				if !slices.Contains(data, "date|"+d) {
+					t.Errorf("нет кнопки %s", d)
+				}
+			}
+			for _, d := range tt.denied {
+				if slices.Contains(data, "date|"+d) {
+					t.Errorf("лишняя кнопка %s", d)
+				}
+			}
+		})
+	}
+}
+
+func TestMonthAllowed(t *testing.T) {
+	current := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	month := func(y int, m time.Month) time.Time { return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		month time.Time
+		rng   pickerRange
+		want  bool
+	}{
+		{month(2026, 10), pickFuture, true},
+		{month(2026, 9), pickFuture, false},
+		{month(2028, 10), pickFuture, true},
+		{month(2028, 11), pickFuture, false},
+		{month(2026, 10), pickPast, true},
+		{month(2026, 11), pickPast, false},
+		{month(2024, 10), pickPast, true},
+		{month(2024, 9), pickPast, false},
+	}
+
+	for _, tt := range tests {
+		if got := monthAllowed(tt.month, current, tt.rng); got != tt.want {
+			t.Errorf("monthAllowed(%s, %s) = %v", tt.month.Format("2006-01"), tt.rng, got)
+		}
+	}
+}
+
+func TestPickerInGroup(t *testing.T) {
+	app, tg := newTestBot(t)
+	day := time.Now().In(testZone).AddDate(0, 0, 1).Format("2006-01-02")
+
+	send(app, testGroup, testUser, "/remind")
+	question := tg.sent("sendMessage")
+	if len(question) != 1 || atoi(question[0].Params["reply_to_message_id"]) == 0 {
+		t.Fatalf("вопрос в группе не ответ на команду: %+v", question)
+	}
+	widget := &tele.Message{ID: 1, Chat: testGroup, Sender: app.bot.Me, ReplyTo: message(testGroup, testUser, "/remind")}
+
+	tg.reset()
+	pressOn(app, widget, testFriend, dateBtn, "d|date|f|"+day)
+	if got := callbackText(tg); got != i18n.T("ru", "picker.not_yours") {
+		t.Errorf("чужое нажатие: %q", got)
+	}
+	if st, _, _ := app.conversations.Get(testGroup.ID, testUser.ID); st.Step != "date" {
+		t.Fatalf("чужое нажатие сдвинуло диалог: %+v", st)
+	}
+
+	// Кнопка от другого шага устарела: виджет убирается
+	tg.reset()
+	pressOn(app, widget, testUser, clockBtn, "t|time|"+day+"|09:30")
+	if got := callbackText(tg); got != i18n.T("ru", "picker.stale") {
+		t.Errorf("устаревшее нажатие: %q", got)
+	}
+	if len(tg.sent("editMessageReplyMarkup")) != 1 {
+		t.Error("устаревший виджет не убран")
+	}
+
+	tg.reset()
+	pressOn(app, widget, testUser, dateBtn, "d|date|f|"+day)
+	if st, _, _ := app.conversations.Get(testGroup.ID, testUser.ID); st.Step != "time" {
+		t.Errorf("выбор дня не сдвинул диалог: %+v", st)
+	}
+}
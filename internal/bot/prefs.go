@@ -0,0 +1,144 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/format"
+	"tg-bot/internal/i18n"
+	"tg-bot/internal/services"
+	"tg-bot/internal/settings"
+)
+
+// chatPrefs — настройки чата, от которых зависит вид ответов
+type chatPrefs struct {
+	loc      *time.Location
+	lang     string // язык интерфейса, см. i18n.Languages
+	units    string // metric или imperial — как у OpenWeather
+	currency services.CurrencyType
+}
+
+// prefs возвращает настройки отображения для чата; при ошибке хранилища — значения по умолчанию
+func (app *BotApp) prefs(chatID int64) chatPrefs {
+	s, err := app.settings.Get(chatID)
+	if err != nil {
+		log.Printf("Не удалось получить настройки чата %d: %v", chatID, err)
+		s = settings.Settings{ChatID: chatID}
+	}
+	return prefsOf(s, app.location)
+}
+
+func prefsOf(s settings.Settings, fallback *time.Location) chatPrefs {
+	return chatPrefs{
+		loc:      s.TimeLocation(fallback),
+		lang:     s.Lang(),
+		units:    s.UnitSystem(),
+		currency: services.CurrencyType(s.BaseCurrency()),
+	}
+}
+
+// prefsFor возвращает настройки для ответа на апдейт. Если язык в настройках
+// не выбран, берётся язык Telegram отправителя, а если он не поддерживается — язык по умолчанию.
+// У inline-запросов чата нет — для них берутся настройки личного чата отправителя.
+func (app *BotApp) prefsFor(c tele.Context) chatPrefs {
+	var chatID int64
+	if chat := c.Chat(); chat != nil {
+		chatID = chat.ID
+	} else if c.Sender() != nil {
+		chatID = c.Sender().ID
+	}
+
+	s, err := app.settings.Get(chatID)
+	if err != nil {
+		log.Printf("Не удалось получить настройки чата %d: %v", chatID, err)
+		s = settings.Settings{ChatID: chatID}
+	}
+
+	p := prefsOf(s, app.location)
+	if s.Language == "" && c.Sender() != nil {
+		if lang := i18n.Normalize(c.Sender().LanguageCode); lang != "" {
+			p.lang = lang
+		}
+	}
+	return p
+}
+
+// t возвращает сообщение на языке чата
+func (p chatPrefs) t(key string, args ...any) string {
+	return i18n.T(p.lang, key, args...)
+}
+
+// n возвращает сообщение с числом в нужной форме множественного числа
+func (p chatPrefs) n(key string, n int, args ...any) string {
+	return i18n.N(p.lang, key, n, args...)
+}
+
+// chatLocation возвращает часовой пояс чата
+func (app *BotApp) chatLocation(chatID int64) *time.Location {
+	return app.prefs(chatID).loc
+}
+
+func (p chatPrefs) imperial() bool {
+	return p.units == "imperial"
+}
+
+// temp форматирует температуру в единицах чата (API уже вернул их в нужной системе)
+func (p chatPrefs) temp(v float64) string {
+	return format.Temp(p.lang, v, p.imperial())
+}
+
+// speed форматирует скорость ветра в единицах чата
+func (p chatPrefs) speed(v float64) string {
+	return format.Speed(p.lang, v, p.imperial())
+}
+
+// wind форматирует ветер с направлением: «СЗ 3,5 м/с»
+func (p chatPrefs) wind(speed float64, deg int64) string {
+	return format.Compass(p.lang, float64(deg)) + " " + p.speed(speed)
+}
+
+// precip форматирует осадки: OpenWeather отдаёт миллиметры, в имперской системе показываем дюймы
+func (p chatPrefs) precip(mm float64) string {
+	return format.Precip(p.lang, mm, p.imperial())
+}
+
+// pressure форматирует давление в гПа или дюймах ртутного столба
+func (p chatPrefs) pressure(hPa int64) string {
+	return format.Pressure(p.lang, float64(hPa), p.imperial())
+}
+
+// number форматирует число с разделителями языка чата
+func (p chatPrefs) number(v float64, prec int) string {
+	return format.Number(p.lang, v, prec)
+}
+
+// money форматирует денежную сумму: «1 234,50 BYN»
+func (p chatPrefs) money(v float64, currency string) string {
+	return format.Money(p.lang, v, currency)
+}
+
+// clock форматирует время суток в часовом поясе чата
+func (p chatPrefs) clock(t time.Time) string {
+	return format.Clock(p.lang, t.In(p.loc))
+}
+
+// when описывает момент относительно сегодняшнего дня чата: «завтра в 9:00»
+func (p chatPrefs) when(t time.Time) string {
+	return format.Relative(p.lang, t, time.Now().In(p.loc))
+}
+
+// toMetric переводит температуру и скорость ветра в °C и м/с — в них заданы пороги советов
+func (p chatPrefs) toMetric(temp, speed float64) (float64, float64) {
+	if !p.imperial() {
+		return temp, speed
+	}
+	return (temp - 32) * 5 / 9, speed * 0.44704
+}
+
+// date форматирует дату на языке чата: «Пятница, 20 июня 2025», «Friday, June 20 2025»
+func (p chatPrefs) date(t time.Time) string {
+	return fmt.Sprintf("%s, %s %d", i18n.DayName(p.lang, t), i18n.DayMonth(p.lang, t), t.Year())
+}
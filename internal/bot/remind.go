@@ -0,0 +1,397 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/reminders"
+	"tg-bot/internal/settings"
+)
+
+// maxForeignReminders — сколько активных напоминаний для других может создать один человек
+const maxForeignReminders = 20
+
+// privateWords — слово «лично» на всех языках интерфейса
+var privateWords = map[string]bool{"лично": true, "private": true, "асабіста": true}
+
+// remindRequest — разобранная команда /remind
+type remindRequest struct {
+	username string // кому напомнить, без @; пусто — всему чату
+	private  bool   // доставить упомянутому в личные сообщения
+	when     time.Time
+	text     string
+}
+
+// parseRemind разбирает аргументы /remind: адресата, режим доставки, время и текст.
+// Текст можно не указывать, если команда — ответ на сообщение (allowEmptyText).
+func parseRemind(payload string, now time.Time, allowEmptyText bool) (remindRequest, error) {
+	var req remindRequest
+	fields := strings.Fields(payload)
+
+options:
+	for len(fields) > 0 {
+		switch {
+		case strings.HasPrefix(fields[0], "@") && len(fields[0]) > 1 && req.username == "":
+			req.username = strings.TrimPrefix(fields[0], "@")
+		case privateWords[strings.ToLower(fields[0])]:
+			req.private = true
+		default:
+			break options
+		}
+		fields = fields[1:]
+	}
+
+	when, used, err := parseWhen(fields, now)
+	if err != nil {
+		return req, err
+	}
+	req.when = when
+	req.text = strings.Join(fields[used:], " ")
+	if req.text == "" && !allowEmptyText {
+		return req, fmt.Errorf("не указан текст напоминания")
+	}
+
+	return req, nil
+}
+
+// handleRemind создаёт напоминание для текущего чата, упомянутого участника
+// группы или (со словом «лично») для него в личные сообщения
+func (app *BotApp) handleRemind(c tele.Context) error {
+	m := c.Message()
+	p := app.prefsFor(c)
+	now := time.Now().In(p.loc)
+
+	if strings.TrimSpace(m.Payload) == "" && m.ReplyTo == nil {
+		return app.startFlow(c, "remind")
+	}
+
+	req, err := parseRemind(m.Payload, now, m.ReplyTo != nil)
+	if err != nil {
+		return c.Send(p.t("remind.usage"))
+	}
+	if req.text == "" {
+		req.text = replyPreview(p, m.ReplyTo)
+	}
+	if !req.when.After(now) {
+		return c.Send(p.t("remind.past_time"))
+	}
+
+	rem := reminders.Reminder{
+		ChatID:   m.Chat.ID,
+		Text:     req.text,
+		Time:     req.when,
+		AuthorID: m.Sender.ID,
+	}
+
+	// Ответ на сообщение: при доставке вернём его вместе с напоминанием
+	if m.ReplyTo != nil {
+		rem.SourceChatID = m.Chat.ID
+		rem.SourceMessageID = m.ReplyTo.ID
+	}
+
+	self := req.username == "" || strings.EqualFold(req.username, m.Sender.Username)
+	switch {
+	case req.private && self:
+		// «лично» без адресата — себе в личные сообщения
+		rem.ChatID = m.Sender.ID
+
+	case req.private:
+		target, err := app.resolvePrivateTarget(c, p, req.username)
+		if err != nil {
+			return c.Send(err.Error())
+		}
+		rem.ChatID = target
+
+	case !self:
+		if m.Chat.Type == tele.ChatPrivate {
+			return c.Send(p.t("remind.mention_private"))
+		}
+		if _, err := app.groupMember(c, p, req.username); err != nil {
+			return c.Send(err.Error())
+		}
+		rem.Mention = "@" + req.username
+	}
+
+	if isForeign(rem) {
+		if app.countForeignReminders(m.Sender.ID) >= maxForeignReminders {
+			return c.Send(p.n("remind.foreign_limit", maxForeignReminders))
+		}
+	}
+
+	if err := app.storage.Add(rem); err != nil {
+		log.Printf("Ошибка при добавлении напоминания в хранилище: %v", err)
+		return c.Send(p.t("remind.save_failed"))
+	}
+
+	confirm := p.t("remind.set", p.when(req.when))
+	switch {
+	case rem.Mention != "":
+		confirm += p.t("remind.for", rem.Mention)
+	case rem.ChatID != m.Chat.ID && !self:
+		confirm += p.t("remind.for_private", req.username)
+	case rem.ChatID != m.Chat.ID:
+		confirm += p.t("remind.to_private")
+	}
+	return c.Send(confirm + p.t("remind.quote", req.text))
+}
+
+// resolvePrivateTarget находит личный чат участника для доставки «лично».
+// Разрешено только в группе, где состоят оба, и только тем, кто сам запускал бота.
+func (app *BotApp) resolvePrivateTarget(c tele.Context, p chatPrefs, username string) (int64, error) {
+	if c.Chat().Type == tele.ChatPrivate {
+		return 0, errors.New(p.t("remind.private_only_group"))
+	}
+
+	u, err := app.groupMember(c, p, username)
+	if err != nil {
+		return 0, err
+	}
+	if !u.Private {
+		return 0, errors.New(p.t("remind.unknown_user", username))
+	}
+	return u.ID, nil
+}
+
+// groupMember находит упомянутого участника текущей группы по @username.
+// Ошибка — готовый ответ: бот этого пользователя не видел или его нет в группе.
+func (app *BotApp) groupMember(c tele.Context, p chatPrefs, username string) (settings.User, error) {
+	u, ok, err := app.settings.FindUser(username)
+	if err != nil {
+		log.Printf("Не удалось найти пользователя @%s: %v", username, err)
+	}
+	if !ok {
+		return u, errors.New(p.t("remind.unseen_user", username))
+	}
+
+	member, err := app.bot.ChatMemberOf(c.Chat(), &tele.User{ID: u.ID})
+	if err != nil || member.Role == tele.Left || member.Role == tele.Kicked {
+		return u, errors.New(p.t("remind.not_member", username))
+	}
+	return u, nil
+}
+
+// countForeignReminders считает активные напоминания, созданные автором для других
+func (app *BotApp) countForeignReminders(authorID int64) int {
+	n := 0
+	for _, r := range app.storage.ListAll() {
+		if r.AuthorID == authorID && r.State == reminders.StatePending && isForeign(r) {
+			n++
+		}
+	}
+	return n
+}
+
+// isForeign сообщает, адресовано ли напоминание не самому автору и не всему чату:
+// упоминание участника или доставка в чужой личный чат (у личных чатов ID > 0)
+func isForeign(r reminders.Reminder) bool {
+	return r.Mention != "" || (r.ChatID > 0 && r.AuthorID != 0 && r.ChatID != r.AuthorID)
+}
+
+// knownUser — что бот уже запомнил о пользователе (см. rememberUser)
+type knownUser struct {
+	username string
+	private  bool
+}
+
+// rememberUser запоминает @username отправителей, чтобы в группах на них можно было
+// ставить напоминания, а тем, кто писал боту в личку, — доставлять их «лично».
+// Уже запомненное не перечитывается: хранилище видит только новые имена.
+func (app *BotApp) rememberUser(next tele.HandlerFunc) tele.HandlerFunc {
+	return func(c tele.Context) error {
+		chat, sender := c.Chat(), c.Sender()
+		if chat == nil || sender == nil || sender.Username == "" || sender.IsBot {
+			return next(c)
+		}
+
+		private := chat.Type == tele.ChatPrivate
+		if v, ok := app.knownUsers.Load(sender.ID); ok {
+			known := v.(knownUser)
+			if known.username == sender.Username && (known.private || !private) {
+				return next(c)
+			}
+		}
+
+		err := app.settings.RememberUser(sender.Username, settings.User{ID: sender.ID, Private: private})
+		if err != nil {
+			log.Printf("Не удалось запомнить пользователя %d: %v", sender.ID, err)
+			return next(c)
+		}
+		app.knownUsers.Store(sender.ID, knownUser{username: sender.Username, private: private})
+		return next(c)
+	}
+}
+
+// maxPreviewLen — сколько символов исходного сообщения показывать в тексте напоминания
+const maxPreviewLen = 100
+
+// replyPreview формирует текст напоминания из сообщения, на которое ответили
+func replyPreview(p chatPrefs, m *tele.Message) string {
+	text := m.Text
+	if text == "" {
+		text = m.Caption
+	}
+	if text == "" {
+		return p.t("remind.see_message")
+	}
+
+	return shorten(text, maxPreviewLen)
+}
+
+// shorten обрезает текст до n символов, отмечая обрезку многоточием
+func shorten(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) > n {
+		return string(runes[:n]) + "…"
+	}
+	return text
+}
+
+// reminderMessage формирует текст напоминания при доставке
+func reminderMessage(p chatPrefs, r reminders.Reminder) string {
+	if r.Mention != "" {
+		return p.t("remind.delivered_for", r.Mention, r.Text)
+	}
+	return p.t("remind.delivered", r.Text)
+}
+
+// reminderLine — строка списка напоминаний: время, текст, адресат в группе и правило повторения
+func reminderLine(p chatPrefs, r reminders.Reminder) string {
+	line := fmt.Sprintf("• %s — %s", p.when(r.Time), r.Text)
+	if r.Mention != "" {
+		line += p.t("remind.for", r.Mention)
+	}
+	switch r.Repeat {
+	case reminders.RepeatSunset:
+		line += p.t("repeat.sunset", describeSunsetOffset(p, r.Offset))
+	case reminders.RepeatDaily:
+		line += p.t("repeat.daily")
+	case reminders.RepeatWeekly:
+		line += p.t("repeat.weekly")
+	case reminders.RepeatMonthly:
+		line += p.t("repeat.monthly")
+	case reminders.RepeatYearly:
+		line += p.t("repeat.yearly")
+	}
+	return line
+}
+
+// chatReminders возвращает напоминания чата, отсортированные по времени
+func (app *BotApp) chatReminders(chatID int64) []reminders.Reminder {
+	var list []reminders.Reminder
+	for _, r := range app.storage.ListAll() {
+		if r.ChatID == chatID {
+			list = append(list, r)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Time.Before(list[j].Time) })
+	return list
+}
+
+// groupReminders возвращает запланированные напоминания групп, которые создал пользователь
+// или в которых его упомянули, — их видно в /reminders личного чата
+func (app *BotApp) groupReminders(userID int64, username string) []reminders.Reminder {
+	var list []reminders.Reminder
+	for _, r := range app.storage.ListAll() {
+		if r.ChatID > 0 || r.State != reminders.StatePending {
+			continue
+		}
+		if r.AuthorID == userID || (username != "" && strings.EqualFold(r.Mention, "@"+username)) {
+			list = append(list, r)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Time.Before(list[j].Time) })
+	return list
+}
+
+// handleReminders показывает запланированные и недоставленные напоминания чата,
+// в группе — общие для всех участников. В личном чате к ним добавляются напоминания
+// из групп, которые создал пользователь или в которых он упомянут.
+// /reminders clear удаляет недоставленные, напоминания по месту удаляются кнопками.
+func (app *BotApp) handleReminders(c tele.Context) error {
+	p := app.prefsFor(c)
+
+	if strings.TrimSpace(c.Message().Payload) == "clear" {
+		removed := 0
+		for _, r := range app.chatReminders(c.Chat().ID) {
+			if r.State != reminders.StateDead {
+				continue
+			}
+			if err := app.storage.Delete(r.ID); err != nil {
+				log.Printf("Не удалось удалить напоминание %s: %v", r.ID, err)
+				continue
+			}
+			removed++
+		}
+		return c.Send(p.n("reminders.cleared", removed))
+	}
+
+	msg, markup := app.remindersList(c, p)
+	return c.Send(msg, markup)
+}
+
+// remindersList формирует список /reminders и кнопки удаления напоминаний по месту
+func (app *BotApp) remindersList(c tele.Context, p chatPrefs) (string, *tele.ReplyMarkup) {
+	list := app.chatReminders(c.Chat().ID)
+	geoList := app.geo.ListByChat(c.Chat().ID)
+	var groupList []reminders.Reminder
+	if c.Chat().Type == tele.ChatPrivate {
+		groupList = app.groupReminders(c.Sender().ID, c.Sender().Username)
+	}
+	if len(list) == 0 && len(geoList) == 0 && len(groupList) == 0 {
+		return p.t("reminders.none"), nil
+	}
+
+	var pending, dead strings.Builder
+	for _, r := range list {
+		line := reminderLine(p, r)
+
+		if r.State == reminders.StateDead {
+			dead.WriteString(line + p.t("reminders.reason", r.LastError))
+			continue
+		}
+		if r.Attempts > 0 {
+			line += p.n("reminders.retrying", r.Attempts)
+		}
+		pending.WriteString(line + "\n")
+	}
+
+	var places strings.Builder
+	for _, r := range geoList {
+		places.WriteString(p.t("reminders.place", r.Lat, r.Lon, int(r.Radius), r.Text))
+	}
+
+	var groups strings.Builder
+	titles := make(map[int64]string)
+	for _, r := range groupList {
+		title, ok := titles[r.ChatID]
+		if !ok {
+			title = app.chatTitle(p, r.ChatID)
+			titles[r.ChatID] = title
+		}
+		groups.WriteString(reminderLine(p, r) + p.t("reminders.group_title", title) + "\n")
+	}
+
+	msg := ""
+	if pending.Len() > 0 {
+		msg += p.t("reminders.pending") + pending.String()
+	}
+	if places.Len() > 0 {
+		msg += p.t("reminders.places") + places.String()
+	}
+	if groups.Len() > 0 {
+		msg += p.t("reminders.groups") + groups.String()
+	}
+	if dead.Len() > 0 {
+		msg += p.t("reminders.dead") + dead.String() + p.t("reminders.clear_hint")
+	}
+
+	return msg, geoDeleteButtons(p, geoList)
+}
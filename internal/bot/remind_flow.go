@@ -0,0 +1,85 @@
+package bot
+
+import (
+	"log"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/conversation"
+	"tg-bot/internal/reminders"
+)
+
+// remindFlow — пошаговое создание напоминания: день → время → текст.
+// Запускается командой /remind без аргументов.
+func (app *BotApp) remindFlow() flow {
+	return flow{
+		start: "date",
+		steps: map[string]step{
+			"date": {
+				ask: func(c tele.Context, st *conversation.State) error {
+					p := app.prefsFor(c)
+					return app.prompt(c, st, p.t("remind.ask_date"), app.datePicker(p, "date", pickFuture, time.Now().In(p.loc)))
+				},
+				answer: func(c tele.Context, st *conversation.State, text string) (string, error) {
+					p := app.prefsFor(c)
+					now := time.Now().In(p.loc)
+					day, err := parseDay(text, now, pickFuture)
+					if err != nil {
+						return "date", app.prompt(c, st, p.t("remind.bad_date"), nil)
+					}
+					if day.AddDate(0, 0, 1).Before(now) {
+						return "date", app.prompt(c, st, p.t("remind.past_date"), nil)
+					}
+					st.Data["date"] = day.Format("2006-01-02")
+					return "time", nil
+				},
+			},
+			"time": {
+				ask: func(c tele.Context, st *conversation.State) error {
+					p := app.prefsFor(c)
+					day, err := time.ParseInLocation("2006-01-02", st.Data["date"], p.loc)
+					if err != nil {
+						return app.prompt(c, st, p.t("remind.ask_time_text"), nil)
+					}
+					return app.prompt(c, st, p.t("remind.ask_time"), app.timePicker("time", day))
+				},
+				answer: func(c tele.Context, st *conversation.State, text string) (string, error) {
+					p := app.prefsFor(c)
+					now := time.Now().In(p.loc)
+					when, err := time.ParseInLocation("2006-01-02 15:04", st.Data["date"]+" "+text, p.loc)
+					if err != nil {
+						return "time", app.prompt(c, st, p.t("remind.bad_time"), nil)
+					}
+					if !when.After(now) {
+						return "time", app.prompt(c, st, p.t("remind.past_time"), nil)
+					}
+					st.Data["when"] = when.Format(time.RFC3339)
+					return "text", nil
+				},
+			},
+			"text": {
+				ask: func(c tele.Context, st *conversation.State) error {
+					return app.prompt(c, st, app.prefsFor(c).t("remind.ask_text"), nil)
+				},
+				answer: func(c tele.Context, st *conversation.State, text string) (string, error) {
+					p := app.prefsFor(c)
+					if text == "" {
+						return "text", app.prompt(c, st, p.t("remind.empty_text"), nil)
+					}
+					when, err := time.Parse(time.RFC3339, st.Data["when"])
+					if err != nil {
+						return "", c.Send(p.t("remind.broken_dialog"))
+					}
+
+					rem := reminders.Reminder{ChatID: st.ChatID, Text: text, Time: when, AuthorID: st.UserID}
+					if err := app.storage.Add(rem); err != nil {
+						log.Printf("Ошибка при добавлении напоминания в хранилище: %v", err)
+						return "", c.Send(p.t("remind.save_failed"), app.menuMarkup(c, p, mainMenu))
+					}
+					return "", c.Send(p.t("remind.set", p.when(when))+p.t("remind.quote", text), app.menuMarkup(c, p, mainMenu))
+				},
+			},
+		},
+	}
+}
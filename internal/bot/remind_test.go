@@ -0,0 +1,192 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/i18n"
+	"tg-bot/internal/reminders"
+	"tg-bot/internal/settings"
+)
+
+func TestParseRemind(t *testing.T) {
+	now := time.Date(2025, 6, 19, 10, 0, 0, 0, testZone)
+	tomorrow10 := time.Date(2025, 6, 20, 10, 0, 0, 0, testZone)
+
+	tests := []struct {
+		payload   string
+		emptyText bool // команда — ответ на сообщение
+		want      remindRequest
+	}{
+		{"завтра 10:00 позвонить маме", false, remindRequest{when: tomorrow10, text: "позвонить маме"}},
+		{"@ivan завтра 10:00 ревью", false, remindRequest{username: "ivan", when: tomorrow10, text: "ревью"}},
+		{"@ivan лично завтра 10:00 ревью", false, remindRequest{username: "ivan", private: true, when: tomorrow10, text: "ревью"}},
+		{"private @ivan tomorrow 10:00 review", false, remindRequest{username: "ivan", private: true, when: tomorrow10, text: "review"}},
+		{"Асабіста заўтра 10:00 спорт", false, remindRequest{private: true, when: tomorrow10, text: "спорт"}},
+		{"через 2 часа", true, remindRequest{when: now.Add(2 * time.Hour)}},
+		{"@ivan через час", true, remindRequest{username: "ivan", when: now.Add(time.Hour)}},
+		// Второе упоминание — уже часть текста
+		{"@ivan завтра 10:00 передать @petr", false, remindRequest{username: "ivan", when: tomorrow10, text: "передать @petr"}},
+	}
+	for _, tt := range tests {
+		got, err := parseRemind(tt.payload, now, tt.emptyText)
+		if err != nil || got != tt.want {
+			t.Errorf("parseRemind(%q) = %+v, %v; ожидалось %+v", tt.payload, got, err, tt.want)
+		}
+	}
+
+	for _, payload := range []string{"", "@ivan", "лично", "@ завтра 10:00 текст", "завтра 10:00", "вчера 10:00 текст"} {
+		if _, err := parseRemind(payload, now, false); err == nil {
+			t.Errorf("parseRemind(%q) не вернул ошибку", payload)
+		}
+	}
+}
+
+func TestRemindReply(t *testing.T) {
+	app, tg := newTestBot(t)
+	long := strings.Repeat("я", maxPreviewLen+10)
+
+	tests := []struct {
+		name     string
+		original *tele.Message
+		payload  string
+		text     string
+	}{
+		{"текст исходного сообщения", &tele.Message{ID: 5, Text: "купить молоко"}, "через 2 часа", "купить молоко"},
+		{"свой текст важнее", &tele.Message{ID: 6, Text: "купить молоко"}, "через 2 часа и хлеб", "и хлеб"},
+		{"подпись к фото", &tele.Message{ID: 7, Caption: "чек"}, "через час", "чек"},
+		{"без текста", &tele.Message{ID: 8}, "через час", i18n.T("ru", "remind.see_message")},
+		{"длинный текст обрезается", &tele.Message{ID: 9, Text: long}, "через час", string([]rune(long)[:maxPreviewLen]) + "…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := message(privateChat, testUser, "/remind "+tt.payload)
+			tt.original.Chat = privateChat
+			m.ReplyTo = tt.original
+			process(app, m)
+
+			var got *reminders.Reminder
+			for _, r := range app.storage.ListAll() {
+				if r.SourceMessageID == tt.original.ID {
+					got = &r
+				}
+			}
+			if got == nil {
+				t.Fatalf("напоминание не создано, ответ: %q", tg.texts())
+			}
+			if got.Text != tt.text || got.SourceChatID != privateChat.ID || got.ChatID != privateChat.ID {
+				t.Errorf("напоминание %+v, ожидался текст %q", *got, tt.text)
+			}
+		})
+	}
+
+	// Без ответа на сообщение текст обязателен
+	tg.reset()
+	send(app, privateChat, testUser, "/remind через час")
+	if got := lastText(t, tg); got != i18n.T("ru", "remind.usage") {
+		t.Errorf("ответ %q, ожидалась подсказка", got)
+	}
+}
+
+var testFriend = &tele.User{ID: 200, FirstName: "Bob", Username: "Bob_1", LanguageCode: "ru"}
+
+// lastText возвращает текст последнего отправленного сообщения
+func lastText(t *testing.T, tg *fakeTelegram) string {
+	t.Helper()
+	texts := tg.texts()
+	if len(texts) == 0 {
+		t.Fatal("бот ничего не ответил")
+	}
+	return texts[len(texts)-1]
+}
+
+func TestRemindMentionTargets(t *testing.T) {
+	app, tg := newTestBot(t)
+
+	// Бот ещё не видел @bob_1 — упоминать его нельзя
+	send(app, testGroup, testUser, "/remind @bob_1 завтра 10:00 позвонить")
+	if got, want := lastText(t, tg), i18n.T("ru", "remind.unseen_user", "bob_1"); got != want {
+		t.Fatalf("ответ %q, ожидался %q", got, want)
+	}
+
+	// Участник написал в группу — теперь его можно упомянуть, регистр имени не важен
+	send(app, testGroup, testFriend, "/help")
+	send(app, testGroup, testUser, "/remind @bob_1 завтра 10:00 позвонить")
+	list := app.storage.ListAll()
+	if len(list) != 1 || list[0].Mention != "@bob_1" || list[0].ChatID != testGroup.ID {
+		t.Fatalf("напоминания: %+v", list)
+	}
+
+	// «Лично» — только тем, кто писал боту в личку
+	send(app, testGroup, testUser, "/remind @bob_1 лично завтра 10:00 позвонить")
+	if got, want := lastText(t, tg), i18n.T("ru", "remind.unknown_user", "bob_1"); got != want {
+		t.Fatalf("ответ %q, ожидался %q", got, want)
+	}
+	send(app, &tele.Chat{ID: testFriend.ID, Type: tele.ChatPrivate}, testFriend, "/help")
+	// Сообщение в группе после лички не отменяет доставку «лично»
+	send(app, testGroup, testFriend, "/help")
+	send(app, testGroup, testUser, "/remind @bob_1 лично завтра 10:00 позвонить")
+	var private *reminders.Reminder
+	for _, r := range app.storage.ListAll() {
+		if r.ChatID == testFriend.ID {
+			private = &r
+		}
+	}
+	if private == nil || private.AuthorID != testUser.ID {
+		t.Fatalf("напоминание «лично» не создано: %+v", app.storage.ListAll())
+	}
+
+	// Вышедшего из группы упоминать нельзя
+	tg.roles[testFriend.ID] = tele.Left
+	for _, cmd := range []string{"/remind @bob_1 завтра 10:00 позвонить", "/remind @bob_1 лично завтра 10:00 позвонить"} {
+		send(app, testGroup, testUser, cmd)
+		if got, want := lastText(t, tg), i18n.T("ru", "remind.not_member", "bob_1"); got != want {
+			t.Errorf("%s: ответ %q, ожидался %q", cmd, got, want)
+		}
+	}
+	if n := len(app.storage.ListAll()); n != 2 {
+		t.Errorf("напоминаний %d, ожидалось 2", n)
+	}
+}
+
+// countingSettings считает обращения к RememberUser
+type countingSettings struct {
+	settings.Storage
+	remembered int
+}
+
+func (s *countingSettings) RememberUser(username string, u settings.User) error {
+	s.remembered++
+	return s.Storage.RememberUser(username, u)
+}
+
+func TestRememberUserOncePerName(t *testing.T) {
+	app, _ := newTestBot(t)
+	counting := &countingSettings{Storage: app.settings}
+	app.settings = counting
+
+	send(app, testGroup, testFriend, "/help")
+	send(app, testGroup, testFriend, "/help")
+	if counting.remembered != 1 {
+		t.Fatalf("RememberUser вызван %d раз, ожидался один", counting.remembered)
+	}
+	u, ok, _ := app.settings.FindUser("BOB_1")
+	if !ok || u.ID != testFriend.ID || u.Private {
+		t.Fatalf("FindUser = %+v, %v", u, ok)
+	}
+
+	// Первое сообщение в личку и новое имя записываются
+	send(app, &tele.Chat{ID: testFriend.ID, Type: tele.ChatPrivate}, testFriend, "/help")
+	renamed := *testFriend
+	renamed.Username = "bobby"
+	send(app, testGroup, &renamed, "/help")
+	if counting.remembered != 3 {
+		t.Fatalf("RememberUser вызван %d раз, ожидалось 3", counting.remembered)
+	}
+	if u, ok, _ := app.settings.FindUser("bobby"); !ok || u.ID != testFriend.ID {
+		t.Fatalf("новое имя не запомнено: %+v, %v", u, ok)
+	}
+}
@@ -0,0 +1,247 @@
+package bot
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/settings"
+)
+
+// setBtn — кнопки экрана настроек. Данные: раздел и (при выборе) значение,
+// например "tz|Europe/Minsk"; экран правится на месте через EditMessage.
+var setBtn = tele.Btn{Unique: "set"}
+
+// option — вариант настройки: значение и подпись на кнопке (ключ сообщения или текст как есть)
+type option struct {
+	value string
+	label string
+}
+
+var (
+	languageOptions = []option{{"ru", "Русский"}, {"en", "English"}, {"be", "Беларуская"}}
+	unitOptions     = []option{{"metric", "settings.metric"}, {"imperial", "settings.imperial"}}
+	currencyOptions = []option{{"BYN", "BYN"}, {"USD", "USD"}, {"EUR", "EUR"}, {"RUB", "RUB"}}
+	timeZones       = []string{
+		"Europe/Minsk", "Europe/Moscow", "Europe/Vilnius", "Europe/Warsaw",
+		"Europe/Kyiv", "Europe/Berlin", "Europe/London", "Asia/Yekaterinburg",
+		"Asia/Almaty", "Asia/Tbilisi", "America/New_York", "UTC",
+	}
+	briefTimes = []string{"06:00", "06:30", "07:00", "07:30", "08:00", "08:30", "09:00", "09:30", "10:00"}
+)
+
+// settingSection — раздел экрана настроек: кнопка на главном экране и список вариантов
+type settingSection struct {
+	title   string // ключ подписи кнопки раздела
+	prompt  string // ключ подсказки на экране раздела
+	options func() []option
+	current func(s settings.Settings) string
+	apply   func(s *settings.Settings, value string)
+}
+
+// settingSections описывает разделы экрана настроек; порядок кнопок — settingsOrder
+func (app *BotApp) settingSections() map[string]settingSection {
+	return map[string]settingSection{
+		"lang": {
+			title:   "settings.lang",
+			prompt:  "settings.lang_prompt",
+			options: func() []option { return languageOptions },
+			current: settings.Settings.Lang,
+			apply:   func(s *settings.Settings, v string) { s.Language = v },
+		},
+		"tz": {
+			title:  "settings.tz",
+			prompt: "settings.tz_prompt",
+			options: func() []option {
+				list := make([]option, 0, len(timeZones))
+				for _, tz := range timeZones {
+					list = append(list, option{tz, tz})
+				}
+				return list
+			},
+			current: func(s settings.Settings) string { return s.TimeLocation(app.location).String() },
+			apply:   func(s *settings.Settings, v string) { s.TimeZone = v },
+		},
+		"units": {
+			title:   "settings.units",
+			prompt:  "settings.units_prompt",
+			options: func() []option { return unitOptions },
+			current: settings.Settings.UnitSystem,
+			apply:   func(s *settings.Settings, v string) { s.Units = v },
+		},
+		"cur": {
+			title:   "settings.currency",
+			prompt:  "settings.currency_prompt",
+			options: func() []option { return currencyOptions },
+			current: settings.Settings.BaseCurrency,
+			apply:   func(s *settings.Settings, v string) { s.Currency = v },
+		},
+		"brief": {
+			title:  "settings.brief",
+			prompt: "settings.brief_prompt",
+			options: func() []option {
+				list := make([]option, 0, len(briefTimes)+1)
+				for _, t := range briefTimes {
+					list = append(list, option{t, t})
+				}
+				return append(list, option{"off", "settings.brief_disable"})
+			},
+			current: func(s settings.Settings) string {
+				if !s.Brief {
+					return "off"
+				}
+				return s.BriefAt()
+			},
+			apply: func(s *settings.Settings, v string) {
+				if v == "off" {
+					s.Brief = false
+					return
+				}
+				s.Brief, s.BriefTime = true, v
+			},
+		},
+		"loc": {
+			title:  "settings.loc",
+			prompt: "settings.loc_prompt",
+			options: func() []option {
+				return []option{{"reset", "settings.loc_reset"}}
+			},
+			current: func(s settings.Settings) string { return "" },
+			apply:   func(s *settings.Settings, v string) { s.Location = nil },
+		},
+	}
+}
+
+// settingsOrder — порядок кнопок разделов на главном экране
+var settingsOrder = []string{"lang", "tz", "units", "cur", "brief", "loc"}
+
+// settingsText описывает текущие настройки чата
+func (app *BotApp) settingsText(p chatPrefs, s settings.Settings) string {
+	lang := s.Lang()
+	for _, o := range languageOptions {
+		if o.value == lang {
+			lang = o.label
+		}
+	}
+
+	brief := p.t("settings.brief_off")
+	if s.Brief {
+		brief = p.t("settings.brief_at", s.BriefAt())
+	}
+
+	place := p.t("settings.loc_default")
+	if s.Location != nil {
+		place = fmt.Sprintf("%.5f, %.5f", s.Location.Lat, s.Location.Lon)
+	}
+
+	return p.t("settings.text", lang, s.TimeLocation(app.location), p.t("settings."+s.UnitSystem()), s.BaseCurrency(), brief, place)
+}
+
+// settingsHome строит главный экран настроек
+func (app *BotApp) settingsHome(p chatPrefs, s settings.Settings) (string, *tele.ReplyMarkup) {
+	sections := app.settingSections()
+	rm := &tele.ReplyMarkup{}
+
+	btns := make([]tele.Btn, 0, len(settingsOrder)+1)
+	for _, name := range settingsOrder {
+		btns = append(btns, rm.Data(p.t(sections[name].title), setBtn.Unique, name))
+	}
+	btns = append(btns, rm.Data(p.t("settings.close"), setBtn.Unique, "close"))
+
+	rm.Inline(rm.Split(2, btns)...)
+	return app.settingsText(p, s), rm
+}
+
+// settingsSection строит экран выбора значения для раздела name
+func (app *BotApp) settingsSection(p chatPrefs, s settings.Settings, name string) (string, *tele.ReplyMarkup) {
+	section := app.settingSections()[name]
+	current := section.current(s)
+	rm := &tele.ReplyMarkup{}
+
+	btns := make([]tele.Btn, 0)
+	for _, o := range section.options() {
+		label := p.t(o.label)
+		if o.value == current {
+			label = "✅ " + label
+		}
+		btns = append(btns, rm.Data(label, setBtn.Unique, name, o.value))
+	}
+
+	rows := rm.Split(2, btns)
+	rows = append(rows, rm.Row(rm.Data(p.t("settings.back"), setBtn.Unique, "home")))
+	rm.Inline(rows...)
+
+	return app.settingsText(p, s) + "\n\n" + p.t(section.prompt), rm
+}
+
+// valid проверяет, что значение есть среди вариантов раздела
+func (section settingSection) valid(value string) bool {
+	return slices.ContainsFunc(section.options(), func(o option) bool { return o.value == value })
+}
+
+// handleSettings отправляет экран настроек отдельным сообщением (/settings, кнопка меню)
+func (app *BotApp) handleSettings(c tele.Context) error {
+	p := app.prefsFor(c)
+	s, err := app.settings.Get(c.Chat().ID)
+	if err != nil {
+		return c.Send(p.t("settings.load_failed"))
+	}
+	text, rm := app.settingsHome(p, s)
+	return c.Send(text, rm)
+}
+
+// handleSettingsButton обрабатывает кнопки экрана настроек: переход по разделам,
+// выбор значения и закрытие. Экран каждый раз правится на месте — в том числе
+// приветствие, из которого открыли настройки кнопкой settingsBtn.
+func (app *BotApp) handleSettingsButton(c tele.Context) error {
+	chatID := c.Chat().ID
+	p := app.prefsFor(c)
+	args := c.Args()
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	if name == "close" {
+		if err := c.Delete(); err != nil {
+			return c.Edit(p.t("settings.closed"))
+		}
+		return c.Respond()
+	}
+
+	section, known := app.settingSections()[name]
+	toast := ""
+	if known && len(args) > 1 {
+		value := args[1]
+		if !section.valid(value) {
+			return c.Respond(&tele.CallbackResponse{Text: p.t("settings.bad_option")})
+		}
+		if err := app.updateSettings(chatID, func(s *settings.Settings) { section.apply(s, value) }); err != nil {
+			return c.Respond(&tele.CallbackResponse{Text: p.t("settings.save_failed")})
+		}
+		// Язык мог поменяться — экран и подсказка строятся уже на новом
+		p = app.prefsFor(c)
+		toast = p.t("settings.saved")
+		known = false // после выбора возвращаемся на главный экран
+	}
+
+	s, err := app.settings.Get(chatID)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: p.t("settings.load_failed")})
+	}
+
+	var text string
+	var rm *tele.ReplyMarkup
+	if known {
+		text, rm = app.settingsSection(p, s, name)
+	} else {
+		text, rm = app.settingsHome(p, s)
+	}
+
+	if err := c.Edit(text, rm); err != nil && !strings.Contains(err.Error(), "message is not modified") {
+		return err
+	}
+	return c.Respond(&tele.CallbackResponse{Text: toast})
+}
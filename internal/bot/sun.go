@@ -0,0 +1,206 @@
+package bot
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/i18n"
+	"tg-bot/internal/reminders"
+)
+
+// maxSunsetOffset ограничивает смещение напоминания относительно заката
+const maxSunsetOffset = 12 * time.Hour
+
+// moonPhaseKey возвращает ключ названия фазы луны по значению moon_phase из OneCall
+func moonPhaseKey(phase float64) string {
+	switch {
+	case phase < 0.03 || phase > 0.97:
+		return "moon.new"
+	case phase < 0.22:
+		return "moon.waxing_crescent"
+	case phase < 0.28:
+		return "moon.first_quarter"
+	case phase < 0.47:
+		return "moon.waxing_gibbous"
+	case phase < 0.53:
+		return "moon.full"
+	case phase < 0.72:
+		return "moon.waning_gibbous"
+	case phase < 0.78:
+		return "moon.last_quarter"
+	default:
+		return "moon.waning_crescent"
+	}
+}
+
+// formatClock форматирует unix-время как время суток; 0 означает, что события сегодня нет
+func (app *BotApp) formatClock(unix int64, p chatPrefs) string {
+	if unix == 0 {
+		return "—"
+	}
+	return p.clock(time.Unix(unix, 0))
+}
+
+// formatSunMoon формирует сообщение «Солнце и Луна» по прогнозу на день.
+// В полярный день и полярную ночь OneCall не присылает ни восхода, ни заката.
+func (app *BotApp) formatSunMoon(day dailyWeather, p chatPrefs) string {
+	date := time.Unix(day.Dt, 0).In(p.loc)
+	moon := []any{app.formatClock(day.Moonrise, p), app.formatClock(day.Moonset, p), p.t(moonPhaseKey(day.MoonPhase))}
+
+	if day.Sunrise == 0 && day.Sunset == 0 {
+		// Полярную ночь от полярного дня отличает нулевой УФ-индекс: солнце весь день под горизонтом
+		polar := p.t("sun.polar_day")
+		if day.Uvi == 0 {
+			polar = p.t("sun.polar_night")
+		}
+		return p.t("sun.report_polar", append([]any{i18n.DayMonth(p.lang, date), polar}, moon...)...)
+	}
+
+	dayLength := sunDayLength(day, date)
+	return p.t("sun.report", append([]any{
+		i18n.DayMonth(p.lang, date),
+		app.formatClock(day.Sunrise, p), app.formatClock(day.Sunset, p),
+		int(dayLength.Hours()), int(dayLength.Minutes()) % 60,
+	}, moon...)...)
+}
+
+// sunDayLength считает, сколько солнце над горизонтом в сутки date. В дни перед
+// полярным днём и после него солнце может не взойти или не зайти (время 0)
+// или зайти раньше, чем взойдёт, — тогда считаются оба отрезка до полуночи.
+func sunDayLength(day dailyWeather, date time.Time) time.Duration {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	end := start.AddDate(0, 0, 1)
+	rise, set := start, end
+	if day.Sunrise != 0 {
+		rise = time.Unix(day.Sunrise, 0)
+	}
+	if day.Sunset != 0 {
+		set = time.Unix(day.Sunset, 0)
+	}
+
+	if set.Before(rise) {
+		return set.Sub(start) + end.Sub(rise)
+	}
+	return set.Sub(rise)
+}
+
+// dailyForecast возвращает прогноз по дням для сохранённого местоположения чата
+func (app *BotApp) dailyForecast(chatID int64) ([]dailyWeather, error) {
+	fullRes, err := app.weatherFor(chatID)
+	if err != nil {
+		return nil, err
+	}
+	if len(fullRes.Daily) == 0 {
+		return nil, errors.New("в ответе погоды нет прогноза по дням")
+	}
+
+	return fullRes.Daily, nil
+}
+
+// handleSunMoon показывает восход, закат и фазу луны на сегодня
+func (app *BotApp) handleSunMoon(c tele.Context) error {
+	daily, err := app.dailyForecast(c.Chat().ID)
+	if err != nil {
+		return c.Send(err.Error())
+	}
+
+	return c.Send(app.formatSunMoon(daily[0], app.prefsFor(c)))
+}
+
+// nextSunsetReminder вычисляет ближайший момент «закат + offset» позже after
+func (app *BotApp) nextSunsetReminder(chatID int64, offset time.Duration, after time.Time) (time.Time, error) {
+	daily, err := app.dailyForecast(chatID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for _, day := range daily {
+		at := time.Unix(day.Sunset, 0).Add(offset).In(app.chatLocation(chatID))
+		if at.After(after) {
+			return at, nil
+		}
+	}
+
+	return time.Time{}, errors.New("в прогнозе нет подходящего заката")
+}
+
+// nextOccurrence вычисляет следующее срабатывание повторяющегося напоминания
+func (app *BotApp) nextOccurrence(r reminders.Reminder, now time.Time) time.Time {
+	switch r.Repeat {
+	case reminders.RepeatSunset:
+		next, err := app.nextSunsetReminder(r.ChatID, r.Offset, now)
+		if err == nil {
+			return next
+		}
+		// Прогноз недоступен — закат завтра будет примерно в то же время
+		log.Printf("Не удалось вычислить закат для чата %d: %v", r.ChatID, err)
+
+	case reminders.RepeatDaily, reminders.RepeatWeekly, reminders.RepeatMonthly, reminders.RepeatYearly:
+		next, _ := r.Repeat.Advance(r.Time, r.Day)
+		for !next.After(now) {
+			next, _ = r.Repeat.Advance(next, r.Day)
+		}
+		return next
+	}
+
+	next := r.Time.Add(24 * time.Hour)
+	for !next.After(now) {
+		next = next.Add(24 * time.Hour)
+	}
+	return next
+}
+
+// handleRemindSunset создаёт ежедневное напоминание относительно заката:
+// /remind_sunset -30 Закрыть теплицу — за 30 минут до заката
+func (app *BotApp) handleRemindSunset(c tele.Context) error {
+	m := c.Message()
+	p := app.prefsFor(c)
+
+	parts := splitNSpaces(m.Payload, 2)
+	if len(parts) < 2 {
+		return c.Send(p.t("sunset.usage"))
+	}
+
+	minutes, err := strconv.Atoi(parts[0])
+	offset := time.Duration(minutes) * time.Minute
+	if err != nil || offset < -maxSunsetOffset || offset > maxSunsetOffset {
+		return c.Send(p.t("sunset.bad_offset"))
+	}
+
+	next, err := app.nextSunsetReminder(m.Chat.ID, offset, time.Now())
+	if err != nil {
+		log.Printf("Не удалось вычислить закат для чата %d: %v", m.Chat.ID, err)
+		return c.Send(p.t("sunset.failed"))
+	}
+
+	rem := reminders.Reminder{
+		ChatID: m.Chat.ID,
+		Text:   parts[1],
+		Time:   next,
+		Repeat: reminders.RepeatSunset,
+		Offset: offset,
+	}
+	if err := app.storage.Add(rem); err != nil {
+		log.Printf("Ошибка при добавлении напоминания в хранилище: %v", err)
+		return c.Send(p.t("remind.save_failed"))
+	}
+
+	return c.Send(p.t("sunset.saved", describeSunsetOffset(p, offset), p.when(next), rem.Text))
+}
+
+// describeSunsetOffset описывает смещение словами: «за 30 минут до заката»
+func describeSunsetOffset(p chatPrefs, offset time.Duration) string {
+	minutes := int(offset.Minutes())
+	switch {
+	case minutes < 0:
+		return p.t("sunset.before", p.n("plural.minutes", -minutes))
+	case minutes > 0:
+		return p.t("sunset.after", p.n("plural.minutes", minutes))
+	default:
+		return p.t("sunset.at")
+	}
+}
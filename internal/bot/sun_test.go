@@ -0,0 +1,42 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"tg-bot/internal/i18n"
+)
+
+func TestFormatSunMoon(t *testing.T) {
+	app := &BotApp{}
+	p := chatPrefs{lang: "ru", loc: testZone}
+	noon := time.Date(2025, 6, 20, 12, 0, 0, 0, testZone)
+	at := func(h, m int) int64 { return time.Date(2025, 6, 20, h, m, 0, 0, testZone).Unix() }
+
+	tests := []struct {
+		name string
+		day  dailyWeather
+		want string
+	}{
+		{"обычный день", dailyWeather{Sunrise: at(4, 40), Sunset: at(21, 45), Uvi: 7}, "17 ч 05 мин"},
+		{"полярный день", dailyWeather{Uvi: 2.5}, i18n.T("ru", "sun.polar_day")},
+		{"полярная ночь", dailyWeather{}, i18n.T("ru", "sun.polar_night")},
+		{"солнце не заходит", dailyWeather{Sunrise: at(2, 30), Uvi: 3}, "21 ч 30 мин"},
+		{"солнце не восходит", dailyWeather{Sunset: at(1, 15), Uvi: 1}, "1 ч 15 мин"},
+		{"заход раньше восхода", dailyWeather{Sunrise: at(23, 0), Sunset: at(1, 30), Uvi: 1}, "2 ч 30 мин"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.day.Dt = noon.Unix()
+			got := app.formatSunMoon(tt.day, p)
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("нет %q в\n%s", tt.want, got)
+			}
+			if strings.Contains(got, "-") {
+				t.Errorf("отрицательная продолжительность дня:\n%s", got)
+			}
+		})
+	}
+}
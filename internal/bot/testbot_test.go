@@ -0,0 +1,222 @@
+package bot
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+
+	"tg-bot/internal/conversation"
+	"tg-bot/internal/expenses"
+	"tg-bot/internal/reminders"
+	"tg-bot/internal/settings"
+)
+
+// testBotID — ID бота в фейковом Telegram
+const testBotID = 42
+
+// apiCall — запрос бота к Bot API: метод и его параметры
+type apiCall struct {
+	Method string
+	Params map[string]any
+}
+
+// Text возвращает параметр text (или caption) запроса
+func (c apiCall) Text() string {
+	if s, ok := c.Params["text"].(string); ok {
+		return s
+	}
+	s, _ := c.Params["caption"].(string)
+	return s
+}
+
+// Markup возвращает клавиатуру (reply_markup) запроса
+func (c apiCall) Markup() tele.ReplyMarkup {
+	var rm tele.ReplyMarkup
+	if s, ok := c.Params["reply_markup"].(string); ok {
+		json.Unmarshal([]byte(s), &rm)
+	}
+	return rm
+}
+
+// apiError — ответ Bot API с ошибкой
+type apiError struct {
+	Code        int            `json:"error_code"`
+	Description string         `json:"description"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
+
+// fakeTelegram — Bot API в памяти: запоминает запросы и отвечает на них успехом,
+// кроме запросов в чаты из fails
+type fakeTelegram struct {
+	mu    sync.Mutex
+	calls []apiCall
+	roles map[int64]tele.MemberStatus // ID пользователя → его роль для getChatMember
+	fails map[int64]apiError          // ID чата → ошибка, которой отвечать на запросы в него
+}
+
+func (f *fakeTelegram) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+	params := make(map[string]any)
+	if body, _ := io.ReadAll(r.Body); len(body) > 0 {
+		json.Unmarshal(body, &params)
+	}
+
+	f.mu.Lock()
+	f.calls = append(f.calls, apiCall{Method: method, Params: params})
+	role, hasRole := f.roles[atoi(params["user_id"])]
+	fail, failed := f.fails[atoi(params["chat_id"])]
+	f.mu.Unlock()
+
+	if failed {
+		json.NewEncoder(w).Encode(struct {
+			Ok bool `json:"ok"`
+			apiError
+		}{false, fail})
+		return
+	}
+
+	var result any = true
+	switch method {
+	case "sendMessage", "editMessageText", "sendDocument", "sendLocation":
+		result = map[string]any{
+			"message_id": 1,
+			"date":       time.Now().Unix(),
+			"chat":       map[string]any{"id": atoi(params["chat_id"])},
+			"text":       params["text"],
+		}
+	case "getChatMember":
+		if !hasRole {
+			role = tele.Member
+		}
+		result = map[string]any{"status": role, "user": map[string]any{"id": atoi(params["user_id"])}}
+	case "getChat":
+		result = map[string]any{"id": atoi(params["chat_id"]), "type": "group", "title": "Test group"}
+	}
+	json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+}
+
+// atoi читает ID из параметра запроса: telebot передаёт их строками
+func atoi(v any) int64 {
+	var id int64
+	switch v := v.(type) {
+	case string:
+		json.Unmarshal([]byte(v), &id)
+	case float64:
+		id = int64(v)
+	}
+	return id
+}
+
+// sent возвращает запросы с заданным методом
+func (f *fakeTelegram) sent(method string) []apiCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var list []apiCall
+	for _, c := range f.calls {
+		if c.Method == method {
+			list = append(list, c)
+		}
+	}
+	return list
+}
+
+// texts возвращает тексты всех отправленных сообщений
+func (f *fakeTelegram) texts() []string {
+	var list []string
+	for _, c := range f.sent("sendMessage") {
+		list = append(list, c.Text())
+	}
+	return list
+}
+
+// reset забывает сделанные запросы
+func (f *fakeTelegram) reset() {
+	f.mu.Lock()
+	f.calls = nil
+	f.mu.Unlock()
+}
+
+// newTestBot собирает BotApp с хранилищами в памяти поверх фейкового Telegram
+func newTestBot(t *testing.T) (*BotApp, *fakeTelegram) {
+	t.Helper()
+
+	tg := &fakeTelegram{roles: make(map[int64]tele.MemberStatus), fails: make(map[int64]apiError)}
+	srv := httptest.NewServer(tg)
+	t.Cleanup(srv.Close)
+
+	b, err := tele.NewBot(tele.Settings{URL: srv.URL, Token: "test", Synchronous: true, Offline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Me = &tele.User{ID: testBotID, Username: "test_bot", IsBot: true}
+
+	storage := reminders.NewMemoryStorage()
+	app := &BotApp{
+		bot:           b,
+		location:      testZone,
+		storage:       storage,
+		geo:           reminders.NewMemoryGeoStorage(),
+		settings:      settings.NewMemoryStorage(),
+		expenses:      expenses.NewMemoryStorage(),
+		conversations: conversation.NewMemoryStorage(),
+	}
+	app.flows = app.conversationFlows()
+	app.registerHandlers()
+	return app, tg
+}
+
+// testUser, privateChat и testGroup — отправитель и чаты для апдейтов в тестах
+var (
+	testUser    = &tele.User{ID: 100, FirstName: "Ann", Username: "ann", LanguageCode: "ru"}
+	privateChat = &tele.Chat{ID: testUser.ID, Type: tele.ChatPrivate}
+	testGroup   = &tele.Chat{ID: -500, Type: tele.ChatGroup, Title: "Test group"}
+)
+
+// message создаёт сообщение пользователя from в чате chat; команда получает
+// сущность bot_command, как у настоящих апдейтов
+func message(chat *tele.Chat, from *tele.User, text string) *tele.Message {
+	m := &tele.Message{ID: 10, Chat: chat, Sender: from, Text: text, Unixtime: time.Now().Unix()}
+	if strings.HasPrefix(text, "/") {
+		end := strings.IndexByte(text, ' ')
+		if end < 0 {
+			end = len(text)
+		}
+		m.Entities = tele.Entities{{Type: tele.EntityCommand, Offset: 0, Length: len([]rune(text[:end]))}}
+	}
+	return m
+}
+
+// process прогоняет сообщение через бота
+func process(app *BotApp, m *tele.Message) {
+	app.bot.ProcessUpdate(tele.Update{ID: 1, Message: m})
+}
+
+// send прогоняет через бота текстовое сообщение от пользователя from в чате chat
+func send(app *BotApp, chat *tele.Chat, from *tele.User, text string) {
+	process(app, message(chat, from, text))
+}
+
+// press прогоняет через бота нажатие инлайн-кнопки btn с данными data
+// под сообщением бота в чате chat
+func press(app *BotApp, chat *tele.Chat, from *tele.User, btn tele.Btn, data string) {
+	pressOn(app, &tele.Message{ID: 1, Chat: chat, Sender: app.bot.Me, Unixtime: time.Now().Unix()}, from, btn, data)
+}
+
+// pressOn прогоняет через бота нажатие инлайн-кнопки btn под сообщением msg
+func pressOn(app *BotApp, msg *tele.Message, from *tele.User, btn tele.Btn, data string) {
+	app.bot.ProcessUpdate(tele.Update{ID: 2, Callback: &tele.Callback{
+		ID:      "1",
+		Sender:  from,
+		Message: msg,
+		Data:    "\f" + btn.Unique + "|" + data,
+	}})
+}
@@ -0,0 +1,46 @@
+package bot
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TickHandler возвращает HTTP-обработчик, который выполняет один цикл фоновых задач:
+// отправляет «сработавшие» напоминания и утренние сводки, а в первую минуту часа
+// проверяет качество воздуха для оповещений. Нужен там, где нет фоновых горутин
+// (serverless): его раз в минуту дёргает внешний cron, передавая секрет в заголовке
+// Authorization: Bearer. Повторные и одновременные вызовы безопасны — хранилище
+// отдаёт каждое напоминание только одному вызову, а сводка и оповещение отмечаются
+// в настройках чата до отправки.
+func (app *BotApp) TickHandler(secret string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if secret == "" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		now := time.Now()
+		sent := app.sendDueReminders(now.In(app.location))
+		briefs := app.sendMorningBrief(now)
+		if now.Minute() == 0 {
+			app.checkAirAlerts()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]int{"sent": sent, "briefs": briefs})
+	})
+}
@@ -0,0 +1,97 @@
+package bot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"tg-bot/internal/reminders"
+)
+
+func TestTickHandlerAuth(t *testing.T) {
+	app, tg := newTestBot(t)
+
+	tests := []struct {
+		name   string
+		secret string // секрет, с которым создан обработчик
+		method string
+		auth   string
+		want   int
+	}{
+		{"секрет не настроен", "", http.MethodGet, "Bearer ", http.StatusForbidden},
+		{"без заголовка", "cron", http.MethodGet, "", http.StatusForbidden},
+		{"чужой секрет", "cron", http.MethodGet, "Bearer guess", http.StatusForbidden},
+		{"секрет без Bearer", "cron", http.MethodGet, "cronx", http.StatusForbidden},
+		{"PUT", "cron", http.MethodPut, "Bearer cron", http.StatusMethodNotAllowed},
+		{"GET", "cron", http.MethodGet, "Bearer cron", http.StatusOK},
+		{"POST", "cron", http.MethodPost, "Bearer cron", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tg.reset()
+			if err := app.storage.Add(reminders.Reminder{ID: "due", ChatID: privateChat.ID, Text: "полить цветы", Time: time.Now().Add(-time.Minute)}); err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(tt.method, "/api/tick", nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			app.TickHandler(tt.secret).ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("статус %d, ожидался %d", rec.Code, tt.want)
+			}
+			if sent := len(tg.texts()); (sent > 0) != (tt.want == http.StatusOK) {
+				t.Errorf("отправлено %d напоминаний", sent)
+			}
+		})
+	}
+}
+
+func TestTickSendsDueReminders(t *testing.T) {
+	app, tg := newTestBot(t)
+	now := time.Now()
+
+	for _, r := range []reminders.Reminder{
+		{ID: "a", ChatID: privateChat.ID, Text: "первое", Time: now.Add(-2 * time.Minute)},
+		{ID: "b", ChatID: testGroup.ID, Text: "второе", Time: now.Add(-time.Minute)},
+		{ID: "later", ChatID: privateChat.ID, Text: "позже", Time: now.Add(time.Hour)},
+	} {
+		if err := app.storage.Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tick := func() map[string]int {
+		req := httptest.NewRequest(http.MethodPost, "/api/tick", nil)
+		req.Header.Set("Authorization", "Bearer cron")
+		rec := httptest.NewRecorder()
+		app.TickHandler("cron").ServeHTTP(rec, req)
+
+		var res map[string]int
+		if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	if res := tick(); res["sent"] != 2 || res["briefs"] != 0 {
+		t.Errorf("первый вызов: %v", res)
+	}
+	if texts := tg.texts(); len(texts) != 2 {
+		t.Fatalf("отправлено %q", texts)
+	}
+
+	// Повторный вызов не отправляет те же напоминания ещё раз
+	if res := tick(); res["sent"] != 0 {
+		t.Errorf("повторный вызов: %v", res)
+	}
+	if all := app.storage.ListAll(); len(all) != 1 || all[0].ID != "later" {
+		t.Errorf("осталось %+v", all)
+	}
+}
@@ -0,0 +1,125 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// weatherFor запрашивает и разбирает погоду для сохранённого местоположения чата
+func (app *BotApp) weatherFor(chatID int64) (oneDailyWeatherRes, error) {
+	lat, lon := app.chatCoords(chatID)
+	return app.weatherAt(lat, lon, app.prefs(chatID))
+}
+
+// weatherAt запрашивает и разбирает погоду для координат в единицах и на языке p
+func (app *BotApp) weatherAt(lat, lon string, p chatPrefs) (oneDailyWeatherRes, error) {
+	var fullRes oneDailyWeatherRes
+	apiRes, err := app.weatherSvc.GetWeather(lat, lon, p.units, p.lang)
+	if err != nil {
+		return fullRes, err
+	}
+	if err := json.Unmarshal(apiRes, &fullRes); err != nil {
+		return fullRes, fmt.Errorf("не удалось распарсить ответ погоды: %w", err)
+	}
+
+	return fullRes, nil
+}
+
+// formatCurrentWeather формирует компактную карточку текущей погоды
+func (app *BotApp) formatCurrentWeather(cur currentWeather, p chatPrefs) string {
+	date := time.Unix(cur.Dt, 0).In(p.loc)
+
+	weatherDescription := p.t("weather.nodata")
+	if len(cur.Weather) > 0 {
+		weatherDescription = cur.Weather[0].Description
+	}
+
+	msg := p.t("weather.now", p.clock(date), p.temp(cur.Temp), weatherDescription, p.temp(cur.FeelsLike))
+
+	// Ветер и порывы
+	windInfo := p.t("weather.wind", p.wind(cur.Wind_speed, cur.Wind_deg))
+	if cur.Wind_gust > 0 {
+		windInfo += p.t("weather.gusts", p.speed(cur.Wind_gust))
+	}
+	msg += windInfo + "\n"
+
+	msg += p.t("weather.humidity", cur.Humidity)
+
+	// Осадки за последний час
+	if rain1h := cur.Rain["1h"]; rain1h > 0 {
+		msg += p.t("weather.rain_1h", p.precip(rain1h))
+	} else if snow1h := cur.Snow["1h"]; snow1h > 0 {
+		msg += p.t("weather.snow_1h", p.precip(snow1h))
+	}
+
+	return msg
+}
+
+// formatTodayWeather формирует подробный прогноз на день
+func (app *BotApp) formatTodayWeather(day dailyWeather, p chatPrefs) string {
+	date := time.Unix(day.Dt, 0).In(p.loc)
+
+	msg := p.t("weather.day_title", p.date(date))
+
+	if day.Summary != "" {
+		msg += fmt.Sprintf("📝 %s\n\n", day.Summary)
+	}
+
+	msg += p.t("weather.day_temp",
+		p.temp(day.Temp.Min), p.temp(day.Temp.Max),
+		p.temp(day.Temp.Morn), p.temp(day.FeelsLike.Morn),
+		p.temp(day.Temp.Day), p.temp(day.FeelsLike.Day),
+		p.temp(day.Temp.Eve), p.temp(day.FeelsLike.Eve),
+		p.temp(day.Temp.Night), p.temp(day.FeelsLike.Night),
+	)
+
+	// Осадки: вероятность и объём
+	msg += p.t("weather.pop", day.Pop*100)
+	if day.Rain > 0 {
+		msg += p.t("weather.rain", p.precip(day.Rain))
+	}
+	if day.Snow > 0 {
+		msg += p.t("weather.snow", p.precip(day.Snow))
+	}
+
+	msg += p.t("weather.wind_max", p.wind(day.WindSpeed, day.WindDeg))
+	msg += p.t("weather.humidity", day.Humidity)
+	msg += p.t("weather.pressure", p.pressure(day.Pressure))
+	msg += p.t("weather.uvi", p.number(day.Uvi, 1))
+
+	return msg
+}
+
+// handleCurrentWeather показывает текущую погоду
+func (app *BotApp) handleCurrentWeather(c tele.Context) error {
+	fullRes, err := app.weatherFor(c.Chat().ID)
+	if err != nil {
+		return c.Send(err.Error())
+	}
+
+	p := app.prefsFor(c)
+	msg := app.formatCurrentWeather(fullRes.Current, p)
+	msg += app.adviceBlock(c.Chat().ID, p, fullRes)
+
+	return c.Send(msg)
+}
+
+// handleTodayWeather показывает прогноз на сегодня: утро, день, вечер, ночь
+func (app *BotApp) handleTodayWeather(c tele.Context) error {
+	fullRes, err := app.weatherFor(c.Chat().ID)
+	if err != nil {
+		return c.Send(err.Error())
+	}
+	if len(fullRes.Daily) == 0 {
+		return c.Send(app.prefsFor(c).t("weather.no_daily"))
+	}
+
+	p := app.prefsFor(c)
+	msg := app.formatTodayWeather(fullRes.Daily[0], p)
+	msg += app.adviceBlock(c.Chat().ID, p, fullRes)
+
+	return c.Send(msg)
+}
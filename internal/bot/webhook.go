@@ -0,0 +1,93 @@
+package bot
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log"
+	"mime"
+	"net/http"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// maxUpdateSize — ограничение на размер тела запроса с апдейтом.
+// Обычные апдейты занимают единицы килобайт, файлы приходят только ссылками.
+const maxUpdateSize = 1 << 20
+
+// secretHeader — заголовок, в котором Telegram передаёт секрет вебхука
+const secretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// allowedUpdates — типы апдейтов, которые бот обрабатывает
+var allowedUpdates = []string{"message", "edited_message", "callback_query", "inline_query"}
+
+// EnableWebhook включает проверку секрета для ServeHTTP. Сам вебхук в Telegram
+// не регистрируется: это делает RegisterWebhook один раз при запуске сервера
+// или при деплое serverless-версии, а не на каждом холодном старте.
+func (app *BotApp) EnableWebhook(secret string) error {
+	if secret == "" {
+		return errors.New("не задан секрет вебхука (WEBHOOK_SECRET)")
+	}
+	app.webhookSecret = secret
+	return nil
+}
+
+// RegisterWebhook регистрирует вебхук в Telegram с секретом из EnableWebhook
+// и списком нужных апдейтов
+func (app *BotApp) RegisterWebhook(publicURL string) error {
+	if publicURL == "" {
+		return errors.New("не задан адрес вебхука (WEBHOOK_URL)")
+	}
+	if app.webhookSecret == "" {
+		return errors.New("не задан секрет вебхука (WEBHOOK_SECRET)")
+	}
+
+	return app.bot.SetWebhook(&tele.Webhook{
+		Endpoint:       &tele.WebhookEndpoint{PublicURL: publicURL},
+		SecretToken:    app.webhookSecret,
+		AllowedUpdates: allowedUpdates,
+	})
+}
+
+// ServeHTTP принимает апдейты от Telegram в режиме вебхука
+func (app *BotApp) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Без настроенного секрета принимать апдейты небезопасно
+	if app.webhookSecret == "" {
+		log.Println("Вебхук вызван, но секрет не настроен — запрос отклонён")
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	got := r.Header.Get(secretHeader)
+	if subtle.ConstantTimeCompare([]byte(got), []byte(app.webhookSecret)) != 1 {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		http.Error(w, "unsupported media type", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var upd tele.Update
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxUpdateSize)).Decode(&upd); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "request entity too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	// Telebot сам разошлёт апдейт всем зарегистрированным хендлерам. Бот в режиме
+	// вебхука синхронный (см. InitBot), поэтому 200 уходит после обработки:
+	// serverless-функцию могут заморозить сразу после ответа.
+	app.bot.ProcessUpdate(upd)
+	w.WriteHeader(http.StatusOK)
+}
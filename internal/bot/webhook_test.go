@@ -0,0 +1,95 @@
+package bot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegisterWebhook(t *testing.T) {
+	app, tg := newTestBot(t)
+
+	if err := app.RegisterWebhook("https://example.com/api/webhook"); err == nil {
+		t.Error("вебхук зарегистрирован без секрета")
+	}
+	if err := app.EnableWebhook("s3cret"); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.RegisterWebhook(""); err == nil {
+		t.Error("вебхук зарегистрирован без адреса")
+	}
+	if calls := tg.sent("setWebhook"); len(calls) != 0 {
+		t.Fatalf("setWebhook вызван при ошибке: %+v", calls)
+	}
+
+	if err := app.RegisterWebhook("https://example.com/api/webhook"); err != nil {
+		t.Fatal(err)
+	}
+	calls := tg.sent("setWebhook")
+	if len(calls) != 1 {
+		t.Fatalf("setWebhook вызван %d раз", len(calls))
+	}
+	if p := calls[0].Params; p["url"] != "https://example.com/api/webhook" || p["secret_token"] != "s3cret" {
+		t.Errorf("параметры setWebhook: %+v", p)
+	}
+}
+
+func TestServeHTTPChecksSecret(t *testing.T) {
+	app, tg := newTestBot(t)
+	update := `{"update_id":1,"message":{"message_id":10,"date":1750000000,"chat":{"id":100,"type":"private"},"from":{"id":100,"first_name":"Ann","language_code":"ru"},"text":"/help","entities":[{"type":"bot_command","offset":0,"length":5}]}}`
+
+	tests := []struct {
+		name        string
+		enable      bool
+		method      string
+		secret      string
+		contentType string
+		body        string
+		want        int
+	}{
+		{"секрет не настроен", false, http.MethodPost, "s3cret", "application/json", update, http.StatusForbidden},
+		{"без секрета", true, http.MethodPost, "", "application/json", update, http.StatusForbidden},
+		{"чужой секрет", true, http.MethodPost, "guess", "application/json", update, http.StatusForbidden},
+		{"GET", true, http.MethodGet, "s3cret", "application/json", "", http.StatusMethodNotAllowed},
+		{"не JSON", true, http.MethodPost, "s3cret", "text/plain", update, http.StatusUnsupportedMediaType},
+		{"битый JSON", true, http.MethodPost, "s3cret", "application/json", "{", http.StatusBadRequest},
+		{"слишком большой", true, http.MethodPost, "s3cret", "application/json", `{"x":"` + strings.Repeat("a", maxUpdateSize) + `"}`, http.StatusRequestEntityTooLarge},
+		{"апдейт", true, http.MethodPost, "s3cret", "application/json; charset=utf-8", update, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tg.reset()
+			app.webhookSecret = ""
+			if tt.enable {
+				if err := app.EnableWebhook("s3cret"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			req := httptest.NewRequest(tt.method, "/api/webhook", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.secret != "" {
+				req.Header.Set(secretHeader, tt.secret)
+			}
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("статус %d, ожидался %d", rec.Code, tt.want)
+			}
+			// Ответ боту — только на принятый апдейт, и к ответу он уже отправлен
+			if handled := len(tg.texts()) > 0; handled != (tt.want == http.StatusOK) {
+				t.Errorf("апдейт обработан: %v", handled)
+			}
+		})
+	}
+}
+
+func TestEnableWebhookRequiresSecret(t *testing.T) {
+	app, _ := newTestBot(t)
+	if err := app.EnableWebhook(""); err == nil {
+		t.Error("вебхук включён без секрета")
+	}
+}
@@ -0,0 +1,174 @@
+package bot
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errBadWhen = errors.New("не удалось распознать дату/время")
+
+// relativeDays — дни, которые можно назвать словом (на всех языках интерфейса)
+var relativeDays = map[string]int{
+	"сегодня": 0, "завтра": 1, "послезавтра": 2,
+	"today": 0, "tomorrow": 1,
+	"сёння": 0, "заўтра": 1, "паслязаўтра": 2,
+}
+
+// pastDays — прошедшие дни, которые можно назвать словом: их понимает только
+// parseDay для дат в прошлом (траты), напоминание на вчера не имеет смысла
+var pastDays = map[string]int{
+	"вчера": -1, "позавчера": -2,
+	"yesterday": -1,
+	"учора":     -1, "пазаўчора": -2,
+}
+
+// afterWords — слово «через» на всех языках интерфейса
+var afterWords = map[string]bool{"через": true, "in": true, "праз": true}
+
+// parseWhen разбирает время напоминания в начале fields и возвращает момент
+// и количество использованных слов. Поддерживаются форматы:
+//
+//	2025-06-20 15:30
+//	сегодня 15:30 / завтра 10:00 / послезавтра 9:00
+//	15:30 (сегодня, а если уже прошло — завтра)
+//	через 30 минут / через 2 часа / через 3 дня / через час
+//
+// Те же слова понимаются по-английски и по-белорусски: tomorrow 10:00, in 2 hours, праз гадзіну.
+func parseWhen(fields []string, now time.Time) (time.Time, int, error) {
+	if len(fields) == 0 {
+		return time.Time{}, 0, errBadWhen
+	}
+	loc := now.Location()
+	first := strings.ToLower(fields[0])
+
+	if days, ok := relativeDays[first]; ok {
+		if len(fields) < 2 {
+			return time.Time{}, 0, errBadWhen
+		}
+		hour, minute, err := parseClock(fields[1])
+		if err != nil {
+			return time.Time{}, 0, err
+		}
+		return time.Date(now.Year(), now.Month(), now.Day()+days, hour, minute, 0, 0, loc), 2, nil
+	}
+	if afterWords[first] {
+		return parseAfter(fields[1:], now)
+	}
+
+	if len(fields) >= 2 {
+		if t, err := time.ParseInLocation("2006-01-02 15:04", fields[0]+" "+fields[1], loc); err == nil {
+			return t, 2, nil
+		}
+	}
+
+	if hour, minute, err := parseClock(fields[0]); err == nil {
+		t := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, loc)
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, 1, nil
+	}
+
+	return time.Time{}, 0, errBadWhen
+}
+
+// parseAfter разбирает «N единиц» после слова «через»
+func parseAfter(fields []string, now time.Time) (time.Time, int, error) {
+	if len(fields) == 0 {
+		return time.Time{}, 0, errBadWhen
+	}
+
+	// «через час» — без числа
+	if unit, ok := timeUnit(fields[0]); ok {
+		return addUnits(now, 1, unit), 2, nil
+	}
+
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n <= 0 || len(fields) < 2 {
+		return time.Time{}, 0, errBadWhen
+	}
+	unit, ok := timeUnit(fields[1])
+	if !ok {
+		return time.Time{}, 0, errBadWhen
+	}
+	return addUnits(now, n, unit), 3, nil
+}
+
+// timeUnit распознаёт единицу времени во всех падежных формах
+func timeUnit(word string) (string, bool) {
+	switch strings.ToLower(strings.TrimSuffix(word, ".")) {
+	case "мин", "минуту", "минуты", "минут", "min", "minute", "minutes", "хв", "хвіліну", "хвіліны", "хвілін":
+		return "minute", true
+	case "ч", "час", "часа", "часов", "h", "hour", "hours", "гадзіну", "гадзіны", "гадзін":
+		return "hour", true
+	case "день", "дня", "дней", "day", "days", "дзень", "дні", "дзён":
+		return "day", true
+	case "неделю", "недели", "недель", "week", "weeks", "тыдзень", "тыдні", "тыдняў":
+		return "week", true
+	}
+	return "", false
+}
+
+func addUnits(now time.Time, n int, unit string) time.Time {
+	switch unit {
+	case "minute":
+		return now.Add(time.Duration(n) * time.Minute)
+	case "hour":
+		return now.Add(time.Duration(n) * time.Hour)
+	case "day":
+		return now.AddDate(0, 0, n)
+	default:
+		return now.AddDate(0, 0, 7*n)
+	}
+}
+
+// parseClock разбирает время вида 9:00 или 15:30
+func parseClock(s string) (int, int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, 0, errBadWhen
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// parseDay разбирает день без времени: сегодня/завтра/послезавтра, 2025-06-20,
+// 20.06.2025 или 20.06. Число без года rng ищет в своём направлении: для
+// pickFuture — ближайшее такое число не раньше сегодня, для pickPast — не позже.
+// Для pickPast понимаются и слова вчера/позавчера.
+func parseDay(s string, now time.Time, rng pickerRange) (time.Time, error) {
+	loc := now.Location()
+	s = strings.ToLower(strings.TrimSpace(s))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	if days, ok := relativeDays[s]; ok {
+		return today.AddDate(0, 0, days), nil
+	}
+	if days, ok := pastDays[s]; ok && rng == pickPast {
+		return today.AddDate(0, 0, days), nil
+	}
+	for _, layout := range []string{"2006-01-02", "2.1.2006"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.ParseInLocation("2.1", s, loc); err == nil {
+		// Ищем ближайший год, где есть такое число: 29.02 бывает только в високосный
+		step := 1
+		if rng == pickPast {
+			step = -1
+		}
+		for year := now.Year(); ; year += step {
+			day := time.Date(year, t.Month(), t.Day(), 0, 0, 0, 0, loc)
+			if day.Day() != t.Day() {
+				continue
+			}
+			if (rng == pickPast && !day.After(today)) || (rng != pickPast && !day.Before(today)) {
+				return day, nil
+			}
+		}
+	}
+
+	return time.Time{}, errBadWhen
+}
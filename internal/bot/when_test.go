@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var testZone = time.FixedZone("UTC+3", 3*60*60)
+
+func TestParseWhen(t *testing.T) {
+	now := time.Date(2025, 6, 19, 10, 0, 0, 0, testZone)
+	at := func(m time.Month, d, h, min int) time.Time { return time.Date(2025, m, d, h, min, 0, 0, testZone) }
+
+	tests := []struct {
+		in    string
+		want  time.Time
+		words int
+	}{
+		{"сегодня 15:30 полить цветы", at(6, 19, 15, 30), 2},
+		{"завтра 10:00", at(6, 20, 10, 0), 2},
+		{"Послезавтра 9:05", at(6, 21, 9, 5), 2},
+		{"tomorrow 9:00", at(6, 20, 9, 0), 2},
+		{"паслязаўтра 7:45", at(6, 21, 7, 45), 2},
+		{"2025-06-20 15:30 купить хлеб", at(6, 20, 15, 30), 2},
+		{"15:30 позвонить", at(6, 19, 15, 30), 1},
+		{"09:00", at(6, 20, 9, 0), 1},  // уже прошло — завтра
+		{"10:00", at(6, 20, 10, 0), 1}, // ровно сейчас — тоже завтра
+		{"через 30 минут", now.Add(30 * time.Minute), 3},
+		{"через час выключить духовку", now.Add(time.Hour), 2},
+		{"через 1 мин.", now.Add(time.Minute), 3},
+		{"in 2 hours", now.Add(2 * time.Hour), 3},
+		{"праз 3 дні", at(6, 22, 10, 0), 3},
+		{"через 2 недели", at(7, 3, 10, 0), 3},
+		{"праз гадзіну", now.Add(time.Hour), 2},
+	}
+	for _, tt := range tests {
+		got, words, err := parseWhen(strings.Fields(tt.in), now)
+		if err != nil || !got.Equal(tt.want) || words != tt.words {
+			t.Errorf("parseWhen(%q) = %v, %d, %v; ожидалось %v, %d", tt.in, got, words, err, tt.want, tt.words)
+		}
+	}
+
+	for _, in := range []string{
+		"", "завтра", "завтра 25:00", "через", "через 0 минут", "через -5 минут",
+		"через 5 лет", "через пять минут", "когда-нибудь", "2025-13-01 10:00",
+	} {
+		if _, _, err := parseWhen(strings.Fields(in), now); err == nil {
+			t.Errorf("parseWhen(%q) не вернул ошибку", in)
+		}
+	}
+}
+
+func TestParseDay(t *testing.T) {
+	now := time.Date(2025, 6, 19, 10, 0, 0, 0, testZone)
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, testZone) }
+
+	tests := []struct {
+		in   string
+		rng  pickerRange
+		want time.Time
+	}{
+		{"сегодня", pickFuture, day(2025, 6, 19)},
+		{" Завтра ", pickFuture, day(2025, 6, 20)},
+		{"tomorrow", pickFuture, day(2025, 6, 20)},
+		{"паслязаўтра", pickFuture, day(2025, 6, 21)},
+		{"2025-06-20", pickFuture, day(2025, 6, 20)},
+		{"2024-01-05", pickFuture, day(2024, 1, 5)},
+		{"20.06.2025", pickFuture, day(2025, 6, 20)},
+		{"5.7.2025", pickFuture, day(2025, 7, 5)},
+		{"20.06", pickFuture, day(2025, 6, 20)},
+		{"19.06", pickFuture, day(2025, 6, 19)}, // сегодняшнее число — сегодня
+		{"18.06", pickFuture, day(2026, 6, 18)}, // прошедшее — в следующем году
+		{"1.1", pickFuture, day(2026, 1, 1)},
+		{"29.02", pickFuture, day(2028, 2, 29)}, // ближайший високосный год
+
+		{"сегодня", pickPast, day(2025, 6, 19)},
+		{"вчера", pickPast, day(2025, 6, 18)},
+		{"Yesterday", pickPast, day(2025, 6, 18)},
+		{"пазаўчора", pickPast, day(2025, 6, 17)},
+		{"15.03", pickPast, day(2025, 3, 15)},
+		{"19.06", pickPast, day(2025, 6, 19)}, // сегодняшнее число — сегодня
+		{"20.06", pickPast, day(2024, 6, 20)}, // будущее — в прошлом году
+		{"29.02", pickPast, day(2024, 2, 29)}, // ближайший прошедший високосный год
+		{"2024-01-05", pickPast, day(2024, 1, 5)},
+	}
+	for _, tt := range tests {
+		got, err := parseDay(tt.in, now, tt.rng)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseDay(%q, %q) = %v, %v; ожидалось %v", tt.in, tt.rng, got, err, tt.want)
+		}
+	}
+
+	for _, rng := range []pickerRange{pickFuture, pickPast} {
+		for _, in := range []string{"", "32.01", "31.04", "29.02.2025", "2025-02-30", "20/06"} {
+			if _, err := parseDay(in, now, rng); err == nil {
+				t.Errorf("parseDay(%q, %q) не вернул ошибку", in, rng)
+			}
+		}
+	}
+	// Вчерашний день для напоминания не имеет смысла
+	if _, err := parseDay("вчера", now, pickFuture); err == nil {
+		t.Error("parseDay(вчера, pickFuture) не вернул ошибку")
+	}
+}
@@ -0,0 +1,151 @@
+package cache
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Loader загружает значение из источника и сообщает, до какого момента оно актуально
+type Loader[V any] func() (V, time.Time, error)
+
+// Cache — потокобезопасный кэш с индивидуальным сроком жизни записей
+// (stale-while-revalidate). Истёкшая, но не слишком старая запись отдаётся
+// сразу, а источник опрашивается в фоне. Одновременные запросы одного ключа
+// объединяются в один вызов источника.
+type Cache[V any] struct {
+	mu       sync.Mutex
+	entries  map[string]entry[V]
+	calls    map[string]*call[V]
+	staleFor time.Duration
+	now      func() time.Time
+}
+
+type entry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// New создаёт кэш. staleFor — сколько ещё можно отдавать запись после
+// истечения её срока, пока она обновляется или если источник недоступен.
+func New[V any](staleFor time.Duration) *Cache[V] {
+	return &Cache[V]{
+		entries:  make(map[string]entry[V]),
+		calls:    make(map[string]*call[V]),
+		staleFor: staleFor,
+		now:      time.Now,
+	}
+}
+
+// Get возвращает значение по ключу. Свежее отдаётся из кэша; устаревшее в
+// пределах staleFor — тоже сразу, а load обновляет его в фоне. Без записи
+// (или если она слишком старая) Get ждёт загрузки.
+func (c *Cache[V]) Get(key string, load Loader[V]) (V, error) {
+	c.mu.Lock()
+	now := c.now()
+	e, ok := c.entries[key]
+	if ok && now.Before(e.expires) {
+		c.mu.Unlock()
+		return e.value, nil
+	}
+
+	cl, inFlight := c.calls[key]
+	if ok && now.Before(e.expires.Add(c.staleFor)) {
+		if !inFlight {
+			cl = c.begin(key)
+			go c.refresh(key, load, cl, now)
+		}
+		c.mu.Unlock()
+		return e.value, nil
+	}
+
+	// Кто-то уже ходит в источник за этим ключом — ждём его результата
+	if inFlight {
+		c.mu.Unlock()
+		<-cl.done
+		return c.result(key, cl)
+	}
+
+	cl = c.begin(key)
+	c.mu.Unlock()
+
+	c.fill(key, load, cl, now)
+	return c.result(key, cl)
+}
+
+// Peek возвращает значение, которое ещё можно отдать, не обращаясь к источнику;
+// false — записи нет или она слишком старая
+func (c *Cache[V]) Peek(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || !c.now().Before(e.expires.Add(c.staleFor)) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// begin регистрирует загрузку ключа; вызывается под c.mu
+func (c *Cache[V]) begin(key string) *call[V] {
+	cl := &call[V]{done: make(chan struct{})}
+	c.calls[key] = cl
+	return cl
+}
+
+// fill загружает значение, сохраняет его и будит всех, кто ждёт этой загрузки
+func (c *Cache[V]) fill(key string, load Loader[V], cl *call[V], now time.Time) {
+	value, expires, err := load()
+
+	c.mu.Lock()
+	if err == nil {
+		c.prune(now)
+		c.entries[key] = entry[V]{value: value, expires: expires}
+	}
+	cl.value, cl.err = value, err
+	delete(c.calls, key)
+	c.mu.Unlock()
+	close(cl.done)
+}
+
+// refresh обновляет устаревшую запись в фоне; при ошибке остаётся старая
+func (c *Cache[V]) refresh(key string, load Loader[V], cl *call[V], now time.Time) {
+	c.fill(key, load, cl, now)
+	if cl.err != nil {
+		log.Printf("Не удалось обновить %q (%v), пока отдаём данные из кэша", key, cl.err)
+	}
+}
+
+// result превращает результат загрузки в ответ, подставляя устаревшее значение при ошибке
+func (c *Cache[V]) result(key string, cl *call[V]) (V, error) {
+	if cl.err == nil {
+		return cl.value, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok && c.now().Before(e.expires.Add(c.staleFor)) {
+		log.Printf("Источник недоступен (%v), отдаём данные из кэша для %q", cl.err, key)
+		return e.value, nil
+	}
+
+	var zero V
+	return zero, cl.err
+}
+
+// prune выбрасывает записи, которые уже нельзя отдать даже как устаревшие
+func (c *Cache[V]) prune(now time.Time) {
+	for k, e := range c.entries {
+		if !now.Before(e.expires.Add(c.staleFor)) {
+			delete(c.entries, k)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// clock — управляемые часы для кэша
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestCache(staleFor time.Duration) (*Cache[string], *clock) {
+	clk := &clock{now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+	c := New[string](staleFor)
+	c.now = clk.Now
+	return c, clk
+}
+
+// waitIdle ждёт, пока завершатся все загрузки, в том числе фоновые
+func waitIdle(t *testing.T, c *Cache[string]) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		n := len(c.calls)
+		c.mu.Unlock()
+		if n == 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("фоновая загрузка не завершилась")
+}
+
+func TestGetDeduplicatesConcurrentLoads(t *testing.T) {
+	c, clk := newTestCache(time.Hour)
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func() (string, time.Time, error) {
+		loads.Add(1)
+		<-release
+		return "v1", clk.Now().Add(time.Minute), nil
+	}
+
+	const n = 10
+	var wg sync.WaitGroup
+	results := make([]string, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := c.Get("k", load)
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = v
+		}(i)
+	}
+
+	// Ждём, пока первый вызов дойдёт до источника, и отпускаем его
+	for loads.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := loads.Load(); got != 1 {
+		t.Errorf("источник вызван %d раз, ожидался 1", got)
+	}
+	for i, v := range results {
+		if v != "v1" {
+			t.Errorf("запрос %d получил %q", i, v)
+		}
+	}
+}
+
+func TestGetServesStaleWhileRevalidating(t *testing.T) {
+	c, clk := newTestCache(time.Hour)
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func() (string, time.Time, error) {
+		if loads.Add(1) == 1 {
+			return "v1", clk.Now().Add(time.Minute), nil
+		}
+		<-release
+		return "v2", clk.Now().Add(time.Minute), nil
+	}
+
+	if v, _ := c.Get("k", load); v != "v1" {
+		t.Fatalf("первый Get = %q", v)
+	}
+
+	// Запись истекла, но ещё в окне staleFor: старое значение отдаётся сразу,
+	// хотя источник завис, и только один фоновый запрос идёт за новым
+	clk.Advance(2 * time.Minute)
+	for i := 0; i < 3; i++ {
+		done := make(chan string)
+		go func() {
+			v, _ := c.Get("k", load)
+			done <- v
+		}()
+		select {
+		case v := <-done:
+			if v != "v1" {
+				t.Fatalf("устаревший Get = %q, ожидалось v1", v)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Get ждёт источник вместо того, чтобы отдать устаревшее значение")
+		}
+	}
+	for deadline := time.Now().Add(time.Second); loads.Load() < 2 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	if got := loads.Load(); got != 2 {
+		t.Errorf("источник вызван %d раз, ожидалось 2 (один фоновый)", got)
+	}
+
+	close(release)
+	waitIdle(t, c)
+	if v, _ := c.Get("k", load); v != "v2" {
+		t.Errorf("после обновления Get = %q, ожидалось v2", v)
+	}
+}
+
+func TestGetKeepsStaleOnRefreshError(t *testing.T) {
+	c, clk := newTestCache(time.Hour)
+
+	fail := errors.New("источник недоступен")
+	var broken atomic.Bool
+	load := func() (string, time.Time, error) {
+		if broken.Load() {
+			return "", time.Time{}, fail
+		}
+		return "v1", clk.Now().Add(time.Minute), nil
+	}
+
+	c.Get("k", load)
+	broken.Store(true)
+
+	clk.Advance(30 * time.Minute)
+	if v, err := c.Get("k", load); err != nil || v != "v1" {
+		t.Fatalf("Get = %q, %v; ожидалось устаревшее v1", v, err)
+	}
+	waitIdle(t, c)
+	if v, err := c.Get("k", load); err != nil || v != "v1" {
+		t.Fatalf("после неудачного обновления Get = %q, %v; ожидалось v1", v, err)
+	}
+
+	// За пределами окна устаревшее значение уже не отдаётся
+	clk.Advance(time.Hour)
+	waitIdle(t, c)
+	if _, err := c.Get("k", load); !errors.Is(err, fail) {
+		t.Errorf("Get за пределами окна вернул %v, ожидалась ошибка источника", err)
+	}
+}
+
+func TestPeekDoesNotLoad(t *testing.T) {
+	c, clk := newTestCache(time.Hour)
+	load := func() (string, time.Time, error) { return "v1", clk.Now().Add(time.Minute), nil }
+
+	if _, ok := c.Peek("k"); ok {
+		t.Fatal("Peek нашёл значение в пустом кэше")
+	}
+	c.Get("k", load)
+
+	clk.Advance(30 * time.Minute)
+	if v, ok := c.Peek("k"); !ok || v != "v1" {
+		t.Errorf("Peek устаревшей записи = %q, %v", v, ok)
+	}
+	clk.Advance(time.Hour)
+	if _, ok := c.Peek("k"); ok {
+		t.Error("Peek отдал запись за пределами окна")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.calls) != 0 {
+		t.Error("Peek запустил загрузку")
+	}
+}
@@ -0,0 +1,238 @@
+// Package caldav — минимальный клиент CalDAV (RFC 4791) для синхронизации
+// напоминаний: чтение всех объектов календаря, запись и удаление по href.
+// Проверялся с Radicale и Nextcloud.
+package caldav
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	resty "resty.dev/v3"
+
+	"tg-bot/internal/ical"
+)
+
+// ErrPrecondition — объект на сервере изменился с момента последнего чтения (412)
+var ErrPrecondition = errors.New("объект в календаре изменён на сервере")
+
+// Object — объект календаря: событие или задача с адресом и версией
+type Object struct {
+	Href  string // путь объекта на сервере
+	ETag  string // версия объекта
+	Event ical.Event
+}
+
+// Client работает с одной коллекцией календаря
+type Client struct {
+	http       *resty.Client
+	collection *url.URL // адрес коллекции, всегда со «/» на конце
+	username   string
+	password   string
+	loc        *time.Location
+}
+
+// NewClient создаёт клиента для коллекции calendarURL, например
+// http://localhost:5232/user/calendar/ (Radicale) или
+// https://cloud.example.com/remote.php/dav/calendars/user/personal/ (Nextcloud).
+// Время без часового пояса в календаре считается временем в loc.
+// Адреса во внутренней сети разрешены только хостам из trusted (см. addressGuard).
+func NewClient(calendarURL, username, password string, loc *time.Location, trusted []string) (*Client, error) {
+	u, err := url.Parse(calendarURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("неверный адрес календаря %q", calendarURL)
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+
+	guard, err := newAddressGuard(u.Hostname(), trusted)
+	if err != nil {
+		return nil, err
+	}
+	transport := &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: dialTimeout, Control: guard.control}).DialContext,
+		TLSHandshakeTimeout: dialTimeout,
+	}
+
+	return &Client{
+		http:       resty.New().SetTransport(transport).SetTimeout(15*time.Second).SetBasicAuth(username, password),
+		collection: u,
+		username:   username,
+		password:   password,
+		loc:        loc,
+	}, nil
+}
+
+// maxResponseSize — ограничение на размер ответа со всем календарём
+const maxResponseSize = 16 << 20
+
+const calendarQuery = `<?xml version="1.0" encoding="utf-8"?>
+<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/><c:calendar-data/></d:prop>
+  <c:filter><c:comp-filter name="VCALENDAR"/></c:filter>
+</c:calendar-query>`
+
+type multistatus struct {
+	Responses []struct {
+		Href      string `xml:"DAV: href"`
+		Propstats []struct {
+			Status string `xml:"DAV: status"`
+			Prop   struct {
+				ETag string `xml:"DAV: getetag"`
+				Data string `xml:"urn:ietf:params:xml:ns:caldav calendar-data"`
+			} `xml:"DAV: prop"`
+		} `xml:"DAV: propstat"`
+	} `xml:"DAV: response"`
+}
+
+// List возвращает все события и задачи календаря. Объекты, которые не удалось
+// разобрать, пропускаются и перечисляются в skipped.
+func (c *Client) List(ctx context.Context) (objects []Object, skipped []string, err error) {
+	// resty не отправляет тело с нестандартными методами, поэтому REPORT
+	// выполняется напрямую через его http.Client
+	req, err := http.NewRequestWithContext(ctx, "REPORT", c.collection.String(), strings.NewReader(calendarQuery))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Depth", "1")
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+
+	res, err := c.http.Client().Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка запроса к календарю: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusMultiStatus {
+		return nil, nil, fmt.Errorf("календарь вернул статус %s", res.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxResponseSize))
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка чтения ответа календаря: %w", err)
+	}
+
+	var ms multistatus
+	if err := xml.Unmarshal(body, &ms); err != nil {
+		return nil, nil, fmt.Errorf("не удалось разобрать ответ календаря: %w", err)
+	}
+
+	for _, r := range ms.Responses {
+		for _, ps := range r.Propstats {
+			if !strings.Contains(ps.Status, " 200 ") || ps.Prop.Data == "" {
+				continue
+			}
+
+			events, err := ical.Parse(strings.NewReader(ps.Prop.Data), c.loc)
+			if err != nil || len(events) == 0 {
+				skipped = append(skipped, c.path(r.Href))
+				continue
+			}
+			// Объект CalDAV — один компонент; остальные VEVENT в нём — исключения
+			// из правила повторения (RECURRENCE-ID), их не поддерживаем
+			objects = append(objects, Object{Href: c.path(r.Href), ETag: ps.Prop.ETag, Event: events[0]})
+		}
+	}
+	return objects, skipped, nil
+}
+
+// Put сохраняет событие. Пустой etag — создать новый объект (если такого ещё нет),
+// иначе — перезаписать объект только этой версии. Возвращает новый ETag,
+// если сервер его сообщил.
+func (c *Client) Put(ctx context.Context, href string, e ical.Event, etag string) (string, error) {
+	var body strings.Builder
+	if err := ical.Encode(&body, ical.ProdID, []ical.Event{e}); err != nil {
+		return "", err
+	}
+
+	req := c.http.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "text/calendar; charset=utf-8").
+		SetBody(body.String())
+	if etag == "" {
+		req.SetHeader("If-None-Match", "*")
+	} else {
+		req.SetHeader("If-Match", etag)
+	}
+
+	res, err := req.Put(c.resolve(href))
+	if err != nil {
+		return "", fmt.Errorf("ошибка записи в календарь: %w", err)
+	}
+	if res.StatusCode() == http.StatusPreconditionFailed {
+		return "", ErrPrecondition
+	}
+	if res.IsError() {
+		return "", fmt.Errorf("календарь вернул статус %s", res.Status())
+	}
+	return res.Header().Get("ETag"), nil
+}
+
+// Delete удаляет объект href только этой версии etag (пустой etag — любой версии).
+// Объект, которого уже нет на сервере, ошибкой не считается.
+func (c *Client) Delete(ctx context.Context, href, etag string) error {
+	req := c.http.R().SetContext(ctx)
+	if etag != "" {
+		req.SetHeader("If-Match", etag)
+	}
+
+	res, err := req.Delete(c.resolve(href))
+	if err != nil {
+		return fmt.Errorf("ошибка удаления из календаря: %w", err)
+	}
+	switch {
+	case res.StatusCode() == http.StatusNotFound || res.StatusCode() == http.StatusGone:
+		return nil
+	case res.StatusCode() == http.StatusPreconditionFailed:
+		return ErrPrecondition
+	case res.IsError():
+		return fmt.Errorf("календарь вернул статус %s", res.Status())
+	}
+	return nil
+}
+
+// Exists проверяет, есть ли объект href на сервере. Отсутствие объекта в ответе
+// на REPORT ещё не значит, что его удалили: ответ мог оказаться неполным.
+func (c *Client) Exists(ctx context.Context, href string) (bool, error) {
+	res, err := c.http.R().SetContext(ctx).Get(c.resolve(href))
+	if err != nil {
+		return false, fmt.Errorf("ошибка запроса к календарю: %w", err)
+	}
+	switch {
+	case res.StatusCode() == http.StatusNotFound || res.StatusCode() == http.StatusGone:
+		return false, nil
+	case res.IsError():
+		return false, fmt.Errorf("календарь вернул статус %s", res.Status())
+	}
+	return true, nil
+}
+
+// Href возвращает путь нового объекта с именем name.ics в коллекции
+func (c *Client) Href(name string) string {
+	return c.collection.Path + name + ".ics"
+}
+
+// path приводит href из ответа сервера (абсолютный путь или полный адрес,
+// с экранированием или без) к пути без экранирования — так же, как Href
+func (c *Client) path(href string) string {
+	ref, err := url.Parse(href)
+	if err != nil {
+		return href
+	}
+	return c.collection.ResolveReference(ref).Path
+}
+
+// resolve превращает путь объекта в полный адрес
+func (c *Client) resolve(href string) string {
+	u := *c.collection
+	u.Path, u.RawPath = href, ""
+	return u.String()
+}
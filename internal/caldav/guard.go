@@ -0,0 +1,68 @@
+package caldav
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// dialTimeout — сколько ждать соединения с сервером календаря
+const dialTimeout = 10 * time.Second
+
+// addressGuard не пускает клиента во внутреннюю сеть: адрес календаря вводит
+// пользователь, и без проверки через бота можно было бы обращаться к сервисам
+// рядом с ним (метаданные облака, базы, админки). Хостам из trusted — например,
+// своему Radicale на том же сервере — внутренние адреса разрешены.
+type addressGuard struct {
+	trusted bool // хост календаря в списке доверенных
+}
+
+// newAddressGuard проверяет хост календаря: доверенный пропускается как есть,
+// остальные не должны разрешаться во внутренние адреса
+func newAddressGuard(host string, trusted []string) (addressGuard, error) {
+	for _, h := range trusted {
+		if strings.EqualFold(h, host) {
+			return addressGuard{trusted: true}, nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return addressGuard{}, fmt.Errorf("не удалось найти сервер календаря %s: %w", host, err)
+	}
+	for _, a := range addrs {
+		if internalIP(a.IP) {
+			return addressGuard{}, fmt.Errorf("адрес календаря %s ведёт во внутреннюю сеть", host)
+		}
+	}
+	return addressGuard{}, nil
+}
+
+// control проверяет адрес каждого соединения уже после разрешения имени:
+// DNS может вернуть другой адрес, чем при создании клиента, а сервер —
+// перенаправить запрос на другой хост
+func (g addressGuard) control(network, address string, _ syscall.RawConn) error {
+	if g.trusted {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || internalIP(ip) {
+		return fmt.Errorf("соединение с внутренним адресом %s запрещено", host)
+	}
+	return nil
+}
+
+// internalIP сообщает, относится ли адрес к внутренней сети: loopback,
+// link-local, частные диапазоны (RFC 1918, RFC 4193) или «любой адрес»
+func internalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsPrivate() || ip.IsUnspecified()
+}
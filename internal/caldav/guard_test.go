@@ -0,0 +1,77 @@
+package caldav
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestNewClientRejectsInternalAddresses(t *testing.T) {
+	for _, u := range []string{
+		"http://127.0.0.1:5232/user/calendar/",
+		"http://localhost/cal/",
+		"http://[::1]/cal/",
+		"http://10.0.0.5/cal/",
+		"http://172.16.1.1/cal/",
+		"http://192.168.1.10/cal/",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://[fe80::1]/cal/",
+		"http://[fd00::1]/cal/",
+		"http://0.0.0.0/cal/",
+	} {
+		if _, err := NewClient(u, "u", "p", time.UTC, nil); err == nil {
+			t.Errorf("NewClient(%s) не вернул ошибку", u)
+		}
+	}
+
+	for _, u := range []string{"https://8.8.8.8/cal/", "https://[2001:4860:4860::8888]/cal/"} {
+		if _, err := NewClient(u, "u", "p", time.UTC, nil); err != nil {
+			t.Errorf("NewClient(%s): %v", u, err)
+		}
+	}
+}
+
+func TestNewClientTrustedHosts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	host := mustHost(t, srv.URL)
+
+	if _, err := NewClient(srv.URL+"/cal/", "u", "p", time.UTC, []string{"other.example"}); err == nil {
+		t.Fatal("NewClient пропустил внутренний адрес, которого нет в списке доверенных")
+	}
+	c, err := NewClient(srv.URL+"/cal/", "u", "p", time.UTC, []string{host})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := c.Exists(context.Background(), c.Href("x")); err != nil || !ok {
+		t.Fatalf("запрос к доверенному хосту: %v, %v", ok, err)
+	}
+}
+
+// Адрес проверяется и при каждом соединении: DNS или перенаправление могут увести во внутреннюю сеть
+func TestClientRejectsRedirectToInternalAddress(t *testing.T) {
+	guard := addressGuard{}
+	for _, addr := range []string{"127.0.0.1:80", "[::1]:443", "10.1.2.3:8080", "169.254.169.254:80"} {
+		if err := guard.control("tcp", addr, nil); err == nil {
+			t.Errorf("соединение с %s разрешено", addr)
+		}
+	}
+	if err := guard.control("tcp", "8.8.8.8:443", nil); err != nil {
+		t.Errorf("соединение с внешним адресом запрещено: %v", err)
+	}
+	if err := (addressGuard{trusted: true}).control("tcp", "127.0.0.1:80", nil); err != nil {
+		t.Errorf("доверенному хосту запрещён внутренний адрес: %v", err)
+	}
+}
+
+func mustHost(t *testing.T, raw string) string {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u.Hostname()
+}
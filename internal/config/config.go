@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -10,12 +11,26 @@ type Config struct {
 	BotToken          string
 	OpenWeatherAPIKey string
 	Location          *time.Location
+	WebhookURL        string   // публичный адрес вебхука: регистрируется в режимах webhook и setup
+	WebhookSecret     string   // секрет для заголовка X-Telegram-Bot-Api-Secret-Token
+	TickSecret        string   // секрет внешнего cron-триггера /api/tick
+	DataFile          string   // файл для постоянного хранения данных (один процесс)
+	KVURL             string   // REST-адрес Upstash / Vercel KV (serverless)
+	KVToken           string   // токен Upstash / Vercel KV
+	CalDAVTrusted     []string // хосты CalDAV во внутренней сети, которые можно подключать (например, свой Radicale)
 }
 
 func LoadConfig() ( *Config, error ) {
 
 	botToken := os.Getenv("TG_BOT_TOKEN")
 	openWeatherAPIKey := os.Getenv("OPEN_API_KEY")
+	webhookURL := os.Getenv("WEBHOOK_URL")
+	webhookSecret := os.Getenv("WEBHOOK_SECRET")
+	tickSecret := os.Getenv("CRON_SECRET")
+	dataFile := os.Getenv("DATA_FILE")
+	kvURL := os.Getenv("KV_REST_API_URL")
+	kvToken := os.Getenv("KV_REST_API_TOKEN")
+	caldavTrusted := strings.FieldsFunc(os.Getenv("CALDAV_TRUSTED_HOSTS"), func(r rune) bool { return r == ',' || r == ' ' })
 
 	loc, err := time.LoadLocation("Europe/Moscow")
 	if err != nil {
@@ -27,5 +42,12 @@ func LoadConfig() ( *Config, error ) {
 		BotToken:          botToken,
 		OpenWeatherAPIKey: openWeatherAPIKey,
 		Location:          loc,
+		WebhookURL:        webhookURL,
+		WebhookSecret:     webhookSecret,
+		TickSecret:        tickSecret,
+		DataFile:          dataFile,
+		KVURL:             kvURL,
+		KVToken:           kvToken,
+		CalDAVTrusted:     caldavTrusted,
 	}, nil
 }
\ No newline at end of file
@@ -0,0 +1,145 @@
+// Package conversation хранит состояние многошаговых диалогов: в каком
+// сценарии и на каком шаге находится участник чата и что он уже ответил.
+package conversation
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"tg-bot/internal/kv"
+)
+
+// State — состояние диалога участника чата. В группе у каждого участника
+// свой диалог, и они не мешают друг другу.
+type State struct {
+	ChatID    int64
+	UserID    int64             // кто ведёт диалог
+	MessageID int               // последнее сообщение этого участника: в группе вопросы шагов — ответы на него
+	Flow      string            // сценарий, например "remind" или "expense"
+	Step      string            // текущий шаг сценария
+	Data      map[string]string // ответы на предыдущих шагах
+	ExpiresAt time.Time         // после этого момента диалог считается брошенным
+}
+
+// Expired сообщает, что пользователь слишком долго не отвечал
+func (s State) Expired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+type Storage interface {
+	Get( chatID, userID int64 ) ( State, bool, error ) // активный диалог участника; false — диалога нет или он истёк
+	Save( s State ) error                              // начать диалог или сохранить переход на новый шаг
+	Delete( chatID, userID int64 ) error               // завершить или отменить диалог
+	Close() error                                      // сбросить несохранённые данные перед остановкой
+}
+
+// key — участник чата, который ведёт диалог
+type key struct {
+	chatID, userID int64
+}
+
+type memoryStorage struct {
+	mu     sync.Mutex
+	states map[key]State
+}
+
+// NewMemoryStorage создаёт in-memory хранилище диалогов
+func NewMemoryStorage() Storage {
+	return &memoryStorage{
+		states: make(map[key]State),
+	}
+}
+
+func (m *memoryStorage) Get(chatID, userID int64) (State, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	k := key{chatID, userID}
+	s, ok := m.states[k]
+	if !ok {
+		return State{}, false, nil
+	}
+	if s.Expired(time.Now()) {
+		delete(m.states, k)
+		return State{}, false, nil
+	}
+	return s, true, nil
+}
+
+func (m *memoryStorage) Save(s State) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[key{s.ChatID, s.UserID}] = s
+	return nil
+}
+
+func (m *memoryStorage) Delete(chatID, userID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.states, key{chatID, userID})
+	return nil
+}
+
+// Close ничего не делает: in-memory хранилищу нечего сбрасывать
+func (m *memoryStorage) Close() error {
+	return nil
+}
+
+// kvStorage хранит диалог каждого участника чата под отдельным ключом:
+// участник пишет только в свой ключ, поэтому общая блокировка не нужна
+type kvStorage struct {
+	store kv.Store
+}
+
+// NewKVStorage создаёт хранилище диалогов поверх постоянного kv.Store —
+// диалог переживает перезапуск бота и работает в serverless-режиме
+func NewKVStorage(store kv.Store) Storage {
+	return &kvStorage{store: store}
+}
+
+func kvKey(chatID, userID int64) string {
+	return "conversation:" + strconv.FormatInt(chatID, 10) + ":" + strconv.FormatInt(userID, 10)
+}
+
+func (s *kvStorage) Get(chatID, userID int64) (State, bool, error) {
+	raw, err := s.store.Get(kvKey(chatID, userID))
+	if errors.Is(err, kv.ErrNotFound) {
+		return State{}, false, nil
+	}
+	if err != nil {
+		return State{}, false, err
+	}
+
+	var st State
+	if err := json.Unmarshal(raw, &st); err != nil {
+		return State{}, false, err
+	}
+	if st.Expired(time.Now()) {
+		return State{}, false, s.Delete(chatID, userID)
+	}
+	return st, true, nil
+}
+
+func (s *kvStorage) Save(st State) error {
+	raw, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	return s.store.Set(kvKey(st.ChatID, st.UserID), raw)
+}
+
+func (s *kvStorage) Delete(chatID, userID int64) error {
+	err := s.store.Delete(kvKey(chatID, userID))
+	if errors.Is(err, kv.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// Close ничего не делает: каждое изменение записывается сразу
+func (s *kvStorage) Close() error {
+	return nil
+}
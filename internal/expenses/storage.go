@@ -0,0 +1,160 @@
+// Package expenses хранит траты, которые пользователи записывают через бота
+package expenses
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"tg-bot/internal/kv"
+)
+
+// Expense — одна трата
+type Expense struct {
+	ID       string
+	ChatID   int64
+	UserID   int64
+	Amount   float64 // сумма в BYN
+	Category string
+	Time     time.Time
+}
+
+type Storage interface {
+	Add( e Expense ) error                                  // записать трату (ID назначается автоматически)
+	ListByChat( chatID int64, since time.Time ) []Expense   // траты чата, начиная с since
+	Close() error                                           // сбросить несохранённые данные перед остановкой
+}
+
+type memoryStorage struct {
+	mu       sync.Mutex
+	expenses []Expense
+}
+
+// NewMemoryStorage создаёт in-memory хранилище трат
+func NewMemoryStorage() Storage {
+	return &memoryStorage{}
+}
+
+func (m *memoryStorage) Add(e Expense) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expenses = append(m.expenses, prepareNew(e))
+	return nil
+}
+
+func (m *memoryStorage) ListByChat(chatID int64, since time.Time) []Expense {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return filter(m.expenses, chatID, since)
+}
+
+// Close ничего не делает: in-memory хранилищу нечего сбрасывать
+func (m *memoryStorage) Close() error {
+	return nil
+}
+
+const (
+	kvPrefix   = "expenses:" // + ID чата + ":" + месяц ГГГГ-ММ: траты чата за месяц
+	kvLockTTL  = 10 * time.Second
+	kvLockWait = 5 * time.Second
+)
+
+// kvStorage хранит траты в kv.Store отдельным ключом на каждый чат и месяц:
+// запись меняет только месяц своего чата, а /expenses читает только нужные месяцы
+type kvStorage struct {
+	store kv.Store
+}
+
+// NewKVStorage создаёт хранилище трат поверх постоянного kv.Store
+func NewKVStorage(store kv.Store) Storage {
+	return &kvStorage{store: store}
+}
+
+// monthKey — ключ трат чата за месяц, в который попадает t (по UTC)
+func monthKey(chatID int64, t time.Time) string {
+	return kvPrefix + strconv.FormatInt(chatID, 10) + ":" + t.UTC().Format("2006-01")
+}
+
+func (s *kvStorage) load(key string) ([]Expense, error) {
+	raw, err := s.store.Get(key)
+	if errors.Is(err, kv.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var list []Expense
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// append дописывает траты в ключ месяца под его блокировкой
+func (s *kvStorage) append(key string, added ...Expense) error {
+	return kv.WithLock(s.store, key+":lock", kvLockTTL, kvLockWait, func() error {
+		list, err := s.load(key)
+		if err != nil {
+			return err
+		}
+
+		raw, err := json.Marshal(append(list, added...))
+		if err != nil {
+			return err
+		}
+		return s.store.Set(key, raw)
+	})
+}
+
+func (s *kvStorage) Add(e Expense) error {
+	return s.append(monthKey(e.ChatID, e.Time), prepareNew(e))
+}
+
+// ListByChat читает месяцы от since до текущего включительно
+func (s *kvStorage) ListByChat(chatID int64, since time.Time) []Expense {
+	now := time.Now().UTC()
+	last := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	since = since.UTC()
+
+	var res []Expense
+	for month := time.Date(since.Year(), since.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(last); month = month.AddDate(0, 1, 0) {
+		list, err := s.load(monthKey(chatID, month))
+		if err != nil {
+			log.Printf("Ошибка хранилища трат (ListByChat): %v", err)
+			return nil
+		}
+		res = append(res, filter(list, chatID, since)...)
+	}
+	return res
+}
+
+// Close ничего не делает: каждое изменение записывается сразу
+func (s *kvStorage) Close() error {
+	return nil
+}
+
+// prepareNew назначает ID новой трате
+func prepareNew(e Expense) Expense {
+	if e.ID == "" {
+		buf := make([]byte, 8)
+		rand.Read(buf)
+		e.ID = hex.EncodeToString(buf)
+	}
+	return e
+}
+
+func filter(list []Expense, chatID int64, since time.Time) []Expense {
+	var res []Expense
+	for _, e := range list {
+		if e.ChatID == chatID && !e.Time.Before(since) {
+			res = append(res, e)
+		}
+	}
+	return res
+}
@@ -0,0 +1,46 @@
+package expenses
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"tg-bot/internal/kv"
+)
+
+func newTestStorage(t *testing.T) (Storage, kv.Store) {
+	t.Helper()
+	store, err := kv.NewFileStore(filepath.Join(t.TempDir(), "kv.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewKVStorage(store), store
+}
+
+func TestKVStorageKeysByChatAndMonth(t *testing.T) {
+	s, store := newTestStorage(t)
+	now := time.Now().UTC()
+	lastMonth := time.Date(now.Year(), now.Month()-1, 15, 12, 0, 0, 0, time.UTC)
+
+	for _, e := range []Expense{
+		{ChatID: 1, Amount: 10, Time: now},
+		{ChatID: 1, Amount: 20, Time: lastMonth},
+		{ChatID: 2, Amount: 30, Time: now},
+	} {
+		if err := s.Add(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := store.Get(monthKey(1, lastMonth)); err != nil {
+		t.Errorf("нет ключа трат чата 1 за прошлый месяц: %v", err)
+	}
+
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if got := s.ListByChat(1, monthStart); len(got) != 1 || got[0].Amount != 10 {
+		t.Errorf("траты чата 1 за месяц: %+v", got)
+	}
+	if got := s.ListByChat(1, lastMonth.Add(-time.Hour)); len(got) != 2 {
+		t.Errorf("траты чата 1 за два месяца: %+v", got)
+	}
+}
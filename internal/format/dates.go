@@ -0,0 +1,49 @@
+package format
+
+import (
+	"fmt"
+	"time"
+
+	"tg-bot/internal/i18n"
+)
+
+// Clock форматирует время суток: «9:00» по-русски и по-белорусски, «9:00 AM» по-английски
+func Clock(lang string, t time.Time) string {
+	if lang == i18n.EN {
+		return t.Format("3:04 PM")
+	}
+	return fmt.Sprintf("%d:%02d", t.Hour(), t.Minute())
+}
+
+// relativeDays — ключи сообщений для дней рядом с сегодняшним
+var relativeDays = map[int]string{
+	-1: "date.yesterday",
+	0:  "date.today",
+	1:  "date.tomorrow",
+	2:  "date.after_tomorrow",
+}
+
+// Relative описывает момент t относительно now: «сегодня в 9:00», «завтра в 9:00»,
+// дальше — «20 июня в 9:00», а для другого года — «20 июня 2026 в 9:00».
+// Дни считаются по календарю в часовом поясе now.
+func Relative(lang string, t, now time.Time) string {
+	t = t.In(now.Location())
+	clock := Clock(lang, t)
+
+	if key, ok := relativeDays[daysBetween(now, t)]; ok {
+		return i18n.T(lang, key, clock)
+	}
+
+	day := i18n.DayMonth(lang, t)
+	if t.Year() != now.Year() {
+		day = fmt.Sprintf("%s %d", day, t.Year())
+	}
+	return i18n.T(lang, "date.at", day, clock)
+}
+
+// daysBetween возвращает число календарных дней от from до to
+func daysBetween(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
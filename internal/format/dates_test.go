@@ -0,0 +1,72 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"tg-bot/internal/i18n"
+)
+
+func TestRelative(t *testing.T) {
+	minsk := time.FixedZone("UTC+3", 3*60*60)
+	now := time.Date(2025, 6, 19, 10, 0, 0, 0, minsk)
+	at := func(y int, m time.Month, d, h, min int) time.Time { return time.Date(y, m, d, h, min, 0, 0, minsk) }
+
+	tests := []struct {
+		lang string
+		t    time.Time
+		now  time.Time
+		want string
+	}{
+		{i18n.RU, at(2025, 6, 19, 15, 30), now, "сегодня в 15:30"},
+		{i18n.RU, at(2025, 6, 19, 0, 5), now, "сегодня в 0:05"},
+		{i18n.RU, at(2025, 6, 18, 23, 59), now, "вчера в 23:59"},
+		{i18n.RU, at(2025, 6, 20, 9, 5), now, "завтра в 9:05"},
+		{i18n.RU, at(2025, 6, 21, 9, 0), now, "послезавтра в 9:00"},
+		{i18n.RU, at(2025, 6, 22, 9, 0), now, "22 июня в 9:00"},
+		{i18n.RU, at(2025, 6, 17, 9, 0), now, "17 июня в 9:00"},
+		{i18n.RU, at(2026, 1, 2, 9, 0), now, "2 января 2026 в 9:00"},
+		{i18n.EN, at(2025, 6, 20, 21, 15), now, "tomorrow at 9:15 PM"},
+		{i18n.EN, at(2025, 6, 22, 9, 0), now, "June 22 at 9:00 AM"},
+		{i18n.BE, at(2025, 6, 22, 9, 0), now, "22 чэрвеня ў 9:00"},
+		// Момент в другом часовом поясе считается по календарю now: 22:30 UTC — это уже 20 июня
+		{i18n.RU, time.Date(2025, 6, 19, 22, 30, 0, 0, time.UTC), now, "завтра в 1:30"},
+		// «Завтра» важнее смены года
+		{i18n.RU, at(2026, 1, 1, 8, 0), at(2025, 12, 31, 23, 0), "завтра в 8:00"},
+	}
+	for _, tt := range tests {
+		if got := Relative(tt.lang, tt.t, tt.now); got != tt.want {
+			t.Errorf("Relative(%q, %v, %v) = %q, ожидалось %q", tt.lang, tt.t, tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestDaysBetween(t *testing.T) {
+	day := func(y int, m time.Month, d, h int) time.Time { return time.Date(y, m, d, h, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		from, to time.Time
+		want     int
+	}{
+		{day(2025, 6, 19, 0), day(2025, 6, 19, 23), 0},
+		{day(2025, 6, 19, 23), day(2025, 6, 20, 0), 1},
+		{day(2025, 6, 20, 0), day(2025, 6, 19, 23), -1},
+		{day(2024, 2, 28, 12), day(2024, 3, 1, 12), 2},
+		{day(2025, 2, 28, 12), day(2025, 3, 1, 12), 1},
+		{day(2025, 12, 31, 23), day(2026, 1, 1, 1), 1},
+		{day(2025, 1, 1, 0), day(2026, 1, 1, 0), 365},
+	}
+	for _, tt := range tests {
+		if got := daysBetween(tt.from, tt.to); got != tt.want {
+			t.Errorf("daysBetween(%v, %v) = %d, ожидалось %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	// Переход на летнее время: сутки длиной 23 часа — всё равно один день
+	if loc, err := time.LoadLocation("Europe/Berlin"); err == nil {
+		from := time.Date(2025, 3, 29, 12, 0, 0, 0, loc)
+		to := time.Date(2025, 3, 31, 0, 30, 0, 0, loc)
+		if got := daysBetween(from, to); got != 2 {
+			t.Errorf("daysBetween через переход на летнее время = %d, ожидалось 2", got)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package format
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"tg-bot/internal/i18n"
+)
+
+// separators — разделители разрядов и дробной части языка
+type separators struct {
+	thousands string
+	decimal   string
+}
+
+// numberSeparators — разделители по языкам; в русском и белорусском разряды
+// отделяются неразрывным пробелом, чтобы число не разрывалось при переносе строки
+var numberSeparators = map[string]separators{
+	i18n.RU: {thousands: "\u00a0", decimal: ","},
+	i18n.BE: {thousands: "\u00a0", decimal: ","},
+	i18n.EN: {thousands: ",", decimal: "."},
+}
+
+func separatorsOf(lang string) separators {
+	if s, ok := numberSeparators[lang]; ok {
+		return s
+	}
+	return numberSeparators[i18n.Default]
+}
+
+// Number форматирует число с prec знаками после запятой и разделителями разрядов языка:
+// «1 234,50» по-русски, «1,234.50» по-английски
+func Number(lang string, v float64, prec int) string {
+	return localize(lang, strconv.FormatFloat(v, 'f', prec, 64))
+}
+
+// Compact форматирует число не более чем с prec знаками после запятой, отбрасывая нули в конце:
+// 3.2456000000000005 → «3,2456», 2.5 → «2,5»
+func Compact(lang string, v float64, prec int) string {
+	scale := math.Pow(10, float64(prec))
+	return localize(lang, strconv.FormatFloat(math.Round(v*scale)/scale, 'f', -1, 64))
+}
+
+// Money форматирует денежную сумму с двумя знаками после запятой и кодом валюты
+// через неразрывный пробел: «1 234,50 BYN»
+func Money(lang string, v float64, currency string) string {
+	return Number(lang, v, 2) + "\u00a0" + currency
+}
+
+// localize расставляет разделители языка в числе, записанном strconv
+func localize(lang, s string) string {
+	sep := separatorsOf(lang)
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	if strings.Trim(whole+frac, "0") == "" {
+		sign = "" // «-0,0» после округления — это просто ноль
+	}
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(sep.thousands)
+		}
+		b.WriteRune(r)
+	}
+	if hasFrac {
+		b.WriteString(sep.decimal + frac)
+	}
+	return b.String()
+}
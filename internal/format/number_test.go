@@ -0,0 +1,66 @@
+package format
+
+import (
+	"testing"
+
+	"tg-bot/internal/i18n"
+)
+
+func TestNumber(t *testing.T) {
+	tests := []struct {
+		lang string
+		v    float64
+		prec int
+		want string
+	}{
+		{i18n.RU, 1234.5, 2, "1\u00a0234,50"},
+		{i18n.BE, 1234.5, 2, "1\u00a0234,50"},
+		{i18n.EN, 1234.5, 2, "1,234.50"},
+		{i18n.EN, 999, 0, "999"},
+		{i18n.EN, 1000, 0, "1,000"},
+		{i18n.EN, 100000, 0, "100,000"},
+		{i18n.EN, 1234567.891, 1, "1,234,567.9"},
+		{i18n.EN, -1234567, 0, "-1,234,567"},
+		{i18n.RU, -12.34, 1, "-12,3"},
+		{i18n.RU, -0.04, 1, "0,0"}, // после округления знак не нужен
+		{i18n.EN, -0.0001, 0, "0"},
+		{i18n.EN, 0, 2, "0.00"},
+		{"xx", 1234, 0, "1\u00a0234"}, // неизвестный язык — разделители языка по умолчанию
+	}
+	for _, tt := range tests {
+		if got := Number(tt.lang, tt.v, tt.prec); got != tt.want {
+			t.Errorf("Number(%q, %v, %d) = %q, ожидалось %q", tt.lang, tt.v, tt.prec, got, tt.want)
+		}
+	}
+}
+
+func TestCompact(t *testing.T) {
+	tests := []struct {
+		lang string
+		v    float64
+		prec int
+		want string
+	}{
+		{i18n.RU, 3.2456000000000005, 4, "3,2456"},
+		{i18n.EN, 2.5, 2, "2.5"},
+		{i18n.EN, 2, 2, "2"},
+		{i18n.EN, 1234.5678, 2, "1,234.57"},
+		{i18n.RU, 0.00004, 4, "0"},
+		{i18n.RU, -0.001, 2, "0"},
+		{i18n.EN, -2.25, 1, "-2.3"},
+	}
+	for _, tt := range tests {
+		if got := Compact(tt.lang, tt.v, tt.prec); got != tt.want {
+			t.Errorf("Compact(%q, %v, %d) = %q, ожидалось %q", tt.lang, tt.v, tt.prec, got, tt.want)
+		}
+	}
+}
+
+func TestMoney(t *testing.T) {
+	if got, want := Money(i18n.RU, 1234.5, "BYN"), "1\u00a0234,50\u00a0BYN"; got != want {
+		t.Errorf("Money = %q, ожидалось %q", got, want)
+	}
+	if got, want := Money(i18n.EN, 5, "USD"), "5.00\u00a0USD"; got != want {
+		t.Errorf("Money = %q, ожидалось %q", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+package format
+
+import (
+	"math"
+	"strings"
+
+	"tg-bot/internal/i18n"
+)
+
+// Коэффициенты перевода в имперские единицы
+const (
+	mmPerInch  = 25.4
+	hPaPerInHg = 33.8639
+)
+
+// Temp форматирует температуру. OpenWeather уже возвращает её в системе единиц чата,
+// поэтому здесь выбирается только обозначение.
+func Temp(lang string, v float64, imperial bool) string {
+	if imperial {
+		return Number(lang, v, 1) + "°F"
+	}
+	return Number(lang, v, 1) + "°C"
+}
+
+// Speed форматирует скорость ветра: м/с или миль/ч, как вернул OpenWeather
+func Speed(lang string, v float64, imperial bool) string {
+	if imperial {
+		return Number(lang, v, 1) + " " + i18n.T(lang, "unit.mph")
+	}
+	return Number(lang, v, 1) + " " + i18n.T(lang, "unit.ms")
+}
+
+// Precip форматирует количество осадков. OpenWeather всегда отдаёт миллиметры,
+// в имперской системе они переводятся в дюймы.
+func Precip(lang string, mm float64, imperial bool) string {
+	if imperial {
+		return Number(lang, mm/mmPerInch, 2) + " " + i18n.T(lang, "unit.in")
+	}
+	return Number(lang, mm, 1) + " " + i18n.T(lang, "unit.mm")
+}
+
+// Pressure форматирует атмосферное давление: гПа или дюймы ртутного столба
+func Pressure(lang string, hPa float64, imperial bool) string {
+	if imperial {
+		return Number(lang, hPa/hPaPerInHg, 2) + " " + i18n.T(lang, "unit.inhg")
+	}
+	return Number(lang, hPa, 0) + " " + i18n.T(lang, "unit.hpa")
+}
+
+// Compass переводит направление ветра в градусах в румб: «С», «СВ», «NE».
+// Румбы языка перечислены в ключе compass через «|», начиная с севера по часовой стрелке.
+func Compass(lang string, deg float64) string {
+	points := strings.Split(i18n.T(lang, "compass"), "|")
+	sector := 360 / float64(len(points))
+
+	i := int(math.Floor((deg+sector/2)/sector)) % len(points)
+	if i < 0 {
+		i += len(points)
+	}
+	return points[i]
+}
@@ -0,0 +1,58 @@
+package format
+
+import (
+	"testing"
+
+	"tg-bot/internal/i18n"
+)
+
+func TestCompass(t *testing.T) {
+	tests := []struct {
+		lang string
+		deg  float64
+		want string
+	}{
+		{i18n.RU, 0, "С"},
+		{i18n.RU, 22.4, "С"},
+		{i18n.RU, 22.5, "СВ"},
+		{i18n.RU, 45, "СВ"},
+		{i18n.RU, 90, "В"},
+		{i18n.RU, 135, "ЮВ"},
+		{i18n.RU, 180, "Ю"},
+		{i18n.RU, 315, "СЗ"},
+		{i18n.RU, 337.4, "СЗ"},
+		{i18n.RU, 337.5, "С"},
+		{i18n.RU, 359, "С"},
+		{i18n.RU, 360, "С"},
+		{i18n.RU, 720 + 90, "В"},
+		{i18n.RU, -10, "С"},
+		{i18n.RU, -30, "СЗ"},
+		{i18n.RU, -90, "З"},
+		{i18n.EN, 225, "SW"},
+		{i18n.EN, 200, "S"},
+	}
+	for _, tt := range tests {
+		if got := Compass(tt.lang, tt.deg); got != tt.want {
+			t.Errorf("Compass(%q, %v) = %q, ожидалось %q", tt.lang, tt.deg, got, tt.want)
+		}
+	}
+}
+
+func TestUnits(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"температура", Temp(i18n.RU, -3.25, false), "-3,2°C"},
+		{"температура °F", Temp(i18n.EN, 71.06, true), "71.1°F"},
+		{"осадки в дюймах", Precip(i18n.EN, 25.4, true), "1.00 " + i18n.T(i18n.EN, "unit.in")},
+		{"давление", Pressure(i18n.RU, 1013.25, false), "1\u00a0013 " + i18n.T(i18n.RU, "unit.hpa")},
+		{"давление в дюймах", Pressure(i18n.EN, 1013.25, true), "29.92 " + i18n.T(i18n.EN, "unit.inhg")},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: %q, ожидалось %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package i18n
+
+// be — сообщения на белорусском языке
+var be = Catalog{
+	"cmd.start":              "Галоўнае меню",
+	"cmd.start.help":         "галоўнае меню з кнопкамі: надвор'е, якасць паветра, сонца і месяц,\nвыдаткі, курсы валют і налады",
+	"cmd.help":               "Спіс каманд",
+	"cmd.help.help":          "спіс каманд, даступных у гэтым чаце",
+	"cmd.remind.args":        "[@карыстальнік] [асабіста] калі тэкст",
+	"cmd.remind":             "Устанавіць напамін",
+	"cmd.remind.help":        "устанавіць напамін. «Калі»: 2025-06-20 15:30, заўтра 10:00, 15:30, праз 2 гадзіны\n(прыклад: /remind заўтра 10:00 Купіць кветкі); без аргументаў — пакрокава\n— у групе можна нагадаць удзельніку: /remind @ivan заўтра 10:00 рэўю;\nса словам «асабіста» напамін прыйдзе яму ў асабістыя паведамленні",
+	"cmd.reminders.args":     "[clear]",
+	"cmd.reminders":          "Спіс напамінаў",
+	"cmd.reminders.help":     "спіс напамінаў, уключаючы недастаўленыя (clear — ачысціць недастаўленыя)\nУ групе — агульныя напаміны чата, у асабістым чаце — яшчэ і вашы напаміны з груп\nНапаміны па месцы выдаляюцца кнопкамі пад спісам",
+	"cmd.remind_sunset.args": "±хвіліны тэкст",
+	"cmd.remind_sunset":      "Штодзённы напамін адносна заходу сонца",
+	"cmd.remind_sunset.help": "штодзённы напамін адносна заходу сонца (прыклад: /remind_sunset -30 Зачыніць цяплічку)",
+	"cmd.remind_at.args":     "[радыус_м] тэкст",
+	"cmd.remind_at":          "Напамін па месцы",
+	"cmd.remind_at.help":     "напамін па месцы: адкажыце на геапазіцыю; спрацуе, калі вы апынецеся побач\nі падзеліцеся трансляцыяй геапазіцыі (прыклад: /remind_at 300 Купіць малако)",
+	"cmd.export.args":        "reminders",
+	"cmd.export":             "Выгрузіць напаміны ў файл .ics",
+	"cmd.export.help":        "выгрузіць напаміны ў файл .ics; дашліце файл .ics, каб імпартаваць падзеі",
+	"cmd.caldav.args":        "[адрас лагін пароль | sync | off]",
+	"cmd.caldav":             "Сінхранізацыя з календаром CalDAV",
+	"cmd.caldav.help":        "сінхранізаваць напаміны з календаром CalDAV (Nextcloud, Radicale)",
+	"cmd.expense":            "Запісаць выдатак",
+	"cmd.expense.help":       "запісаць выдатак: сума, катэгорыя, дзень",
+	"cmd.expenses":           "Выдаткі за месяц",
+	"cmd.expenses.help":      "выдаткі за бягучы месяц па катэгорыях",
+	"cmd.cancel":             "Перапыніць пакрокавы дыялог",
+	"cmd.cancel.help":        "перапыніць пакрокавы дыялог",
+	"cmd.settings":           "Налады чата",
+	"cmd.settings.help":      "мова, гадзінны пояс, адзінкі, базавая валюта, час зводкі і месцазнаходжанне",
+	"cmd.subscribe":          "Падпісацца на ранішнюю зводку",
+	"cmd.subscribe.help":     "падпісацца на штодзённую ранішнюю зводку (час і гадзінны пояс — у /settings)",
+	"cmd.unsubscribe":        "Адпісацца ад ранішняй зводкі",
+	"cmd.unsubscribe.help":   "адпісацца ад ранішняй зводкі",
+	"cmd.brief_air.args":     "on|off",
+	"cmd.brief_air":          "Якасць паветра ў ранішняй зводцы",
+	"cmd.brief_air.help":     "дадаваць у ранішнюю зводку якасць паветра",
+	"cmd.air_alert.args":     "1-4|off",
+	"cmd.air_alert":          "Апавяшчэнні пра якасць паветра",
+	"cmd.air_alert.help":     "паведамляць, калі індэкс якасці паветра стане вышэй за парог",
+	"cmd.advice.args":        "[umbrella|sunscreen|cold|heat|wind значэнне | reset]",
+	"cmd.advice":             "Парогі парад па надвор'і",
+	"cmd.advice.help":        "паглядзець або змяніць парогі парад па надвор'і",
+	"help.footer":            "Адпраўце геапазіцыю, каб надвор'е і якасць паветра паказваліся для вашага месца\n\nУ групе бот адказвае на каманды і згадкі @%[1]s\n\nУ любым чаце: @%[1]s usd, @%[1]s 100 eur, @%[1]s надвор'е Мінск",
+	"help.admins_only":       "🔒 у групе — толькі адміністратары",
+	"group.admins_only":      "🔒 Налады групы могуць мяняць толькі яе адміністратары.",
+	"group.untitled":         "група без назвы",
+
+	"unit.ms":             "м/с",
+	"unit.mph":            "міль/г",
+	"unit.mm":             "мм",
+	"unit.in":             "цал.",
+	"unit.hpa":            "гПа",
+	"unit.inhg":           "цал. рт. сл.",
+	"compass":             "Пн|ПнУ|У|ПдУ|Пд|ПдЗ|З|ПнЗ",
+	"date.yesterday":      "учора ў %s",
+	"date.today":          "сёння ў %s",
+	"date.tomorrow":       "заўтра ў %s",
+	"date.after_tomorrow": "паслязаўтра ў %s",
+	"date.at":             "%s ў %s",
+
+	"weather.nodata":    "няма даных",
+	"weather.now":       "🌡 Зараз (%s): %s, %s\nАдчуваецца як %s\n",
+	"weather.wind":      "🌬️ Вецер: %s",
+	"weather.gusts":     " (парывы да %s)",
+	"weather.humidity":  "💧 Вільготнасць: %.0f%%\n",
+	"weather.rain_1h":   "🌧️ Дождж (за гадзіну): %s\n",
+	"weather.snow_1h":   "❄️ Снег (за гадзіну): %s\n",
+	"weather.day_title": "☀️ Надвор'е на %s:\n\n",
+	"weather.day_temp":  "🌡 Тэмпература: ад %s да %s\n• раніцай %s (адчуваецца як %s)\n• днём %s (адчуваецца як %s)\n• увечары %s (адчуваецца як %s)\n• уначы %s (адчуваецца як %s)\n\n",
+	"weather.pop":       "☔ Верагоднасць ападкаў: %.0f%%\n",
+	"weather.rain":      "🌧️ Дождж: %s\n",
+	"weather.snow":      "❄️ Снег: %s\n",
+	"weather.wind_max":  "🌬️ Вецер: да %s\n",
+	"weather.pressure":  "📊 Ціск: %s\n",
+	"weather.uvi":       "☀️ УФ-індэкс: %s\n",
+	"weather.no_daily":  "У адказе надвор'я няма прагнозу на дзень",
+
+	"sun.report":       "🌅 Сонца і Месяц, %s:\n\n☀️ Усход: %s\n🌇 Захад: %s\n⏳ Працягласць дня: %d г %02d хв\n\n🌙 Усход месяца: %s\n🌙 Захад месяца: %s\nФаза: %s\n",
+	"sun.report_polar": "🌅 Сонца і Месяц, %s:\n\n%s\n\n🌙 Усход месяца: %s\n🌙 Захад месяца: %s\nФаза: %s\n",
+	"sun.polar_day":    "☀️ Палярны дзень: сонца не заходзіць",
+	"sun.polar_night":  "🌑 Палярная ноч: сонца не ўзыходзіць",
+
+	"moon.new":             "🌑 маладзік",
+	"moon.waxing_crescent": "🌒 растучы серп",
+	"moon.first_quarter":   "🌓 першая чвэрць",
+	"moon.waxing_gibbous":  "🌔 растучы месяц",
+	"moon.full":            "🌕 поўня",
+	"moon.waning_gibbous":  "🌖 спадальны месяц",
+	"moon.last_quarter":    "🌗 апошняя чвэрць",
+	"moon.waning_crescent": "🌘 спадальны серп",
+
+	"sunset.before":     "за %s да заходу сонца",
+	"sunset.after":      "праз %s пасля заходу сонца",
+	"sunset.at":         "у момант заходу сонца",
+	"sunset.usage":      "Укажыце зрух у хвілінах і тэкст. Прыклад:\n/remind_sunset -30 Зачыніць цяплічку",
+	"sunset.bad_offset": "Зрух — цэлы лік хвілін ад -720 да 720. Адмоўны — да заходу сонца.",
+	"sunset.failed":     "Не ўдалося атрымаць час заходу сонца. Паспрабуйце пазней.",
+	"sunset.saved":      "Буду нагадваць кожны дзень (%s). Бліжэйшы — %s:\n«%s»",
+
+	"brief.greeting":           "🌞 Добрай раніцы! Сёння %s, %s\n\n",
+	"brief.weather":            "🌡 Надвор'е: %s, %s (адчуваецца як %s)\n",
+	"brief.reminders":          "⏰ Напаміны на сёння:\n",
+	"brief.on":                 "🌅 Ранішняя зводка ўключана: кожны дзень у %s.",
+	"brief.off":                "Ранішняя зводка выключана.",
+	"brief.subscribe_failed":   "Не ўдалося аформіць падпіску. Паспрабуйце пазней.",
+	"brief.subscribed":         "Вы падпісаны на ранішнюю зводку (%s %s). Змяніць час: /settings",
+	"brief.unsubscribe_failed": "Не ўдалося адмяніць падпіску. Паспрабуйце пазней.",
+	"brief.unsubscribed":       "Вы адпісаны ад ранішняй зводкі.",
+	"brief.air_usage":          "Выкарыстоўвайце: /brief_air on або /brief_air off",
+	"brief.air_on":             "Якасць паветра будзе дадавацца ў ранішнюю зводку.",
+	"brief.air_off":            "Якасць паветра больш не будзе дадавацца ў ранішнюю зводку.",
+
+	"air.aqi1":        "добрая",
+	"air.aqi2":        "здавальняючая",
+	"air.aqi3":        "умераная",
+	"air.aqi4":        "дрэнная",
+	"air.aqi5":        "вельмі дрэнная",
+	"air.report":      "🌫 Якасць паветра: %s (AQI %d з 5)\n• PM2.5: %s мкг/м³\n• PM10: %s мкг/м³\n• O₃: %s мкг/м³\n",
+	"air.alert_usage": "Укажыце парог ад 1 да 4 або off. Прыклад:\n/air_alert 3 — паведаміць, калі AQI стане вышэй за 3",
+	"air.alert_off":   "Апавяшчэнні пра якасць паветра выключаны.",
+	"air.alert_on":    "Паведамлю, калі індэкс якасці паветра стане вышэй за %d (%s).",
+	"air.alert":       "⚠️ Якасць паветра пагоршылася!\n\n",
+
+	"advice.title":           "\n💡 Парады:\n",
+	"advice.umbrella":        "☂️ Вазьмі парасон",
+	"advice.sunscreen":       "🧴 Патрэбны сонцаахоўны крэм",
+	"advice.ice":             "🧊 Магчыма галалёдзіца — асцярожней на дарогах",
+	"advice.cold":            "🧣 Апранайся цяплей: шапка і пальчаткі",
+	"advice.heat":            "🥤 Горача — вазьмі ваду і галаўны ўбор",
+	"advice.wind":            "🌬️ Моцны вецер — парасон можа не перажыць прагулку",
+	"advice.reset":           "Парогі парад скінуты да значэнняў па змаўчанні.",
+	"advice.not_number":      "Значэнне павінна быць лікам. Прыклад: /advice umbrella 50",
+	"advice.out_of_range":    "Значэнне %s павінна быць ад %s да %s.",
+	"advice.cold_above_heat": "Парог холаду павінен быць ніжэй за парог спякоты: зараз cold %s, heat %s.",
+	"advice.unknown_rule":    "Невядомае правіла. Даступныя: umbrella, sunscreen, cold, heat, wind.",
+	"advice.usage":           "Выкарыстоўвайце: /advice, /advice <правіла> <значэнне> або /advice reset",
+	"advice.thresholds":      "Парогі парад:\n• umbrella — парасон пры верагоднасці ападкаў ад %.0f%%\n• sunscreen — крэм пры УФ-індэксе ад %.1f\n• cold — апрануцца цяплей пры адчувальнай тэмпературы да %.1f°C\n• heat — спякота пры адчувальнай тэмпературы ад %.1f°C\n• wind — моцны вецер ад %.1f м/с\n\nЗмяніць: /advice umbrella 50, скінуць: /advice reset",
+
+	"geo.usage":         "Напамін па месцы спрацоўвае, калі вы дзеліцеся трансляцыяй геапазіцыі побач з пунктам.\nАдкажыце камандай на паведамленне з геапазіцыяй:\n/remind_at [радыус_м] тэкст\nці ўкажыце каардынаты:\n/remind_at 55.139 27.684 [радыус_м] тэкст\nПрыклад: /remind_at 300 Купіць малако",
+	"geo.bad_coords":    "каардынаты па-за дапушчальным дыяпазонам",
+	"geo.bad_radius":    "радыус павінен быць ад %d да %d метраў",
+	"geo.no_text":       "не ўказаны тэкст напаміну",
+	"geo.saved":         "📍 Нагадаю «%s», калі апынецеся ў %d м ад пункта.\nНапамін спрацуе па трансляцыі геапазіцыі (📎 → Геапазіцыя → Трансляваць).",
+	"geo.delivered_for": "📍 Напамін для @%s: %s",
+	"geo.delivered":     "📍 Напамін: %s",
+	"geo.deleted":       "🗑 Напамін па месцы выдалены",
+	"geo.not_found":     "Напамін ужо выдалены ці спрацаваў",
+	"geo.not_yours":     "Выдаліць напамін можа толькі яго аўтар",
+	"geo.delete_failed": "Не ўдалося выдаліць напамін. Паспрабуйце пазней.",
+
+	"location.save_failed": "Не ўдалося захаваць месцазнаходжанне. Паспрабуйце пазней.",
+	"location.saved":       "📍 Месцазнаходжанне захавана, надвор'е і якасць паветра будуць паказвацца для яго.\nКаб атрымаць напамін, калі апынецеся тут, адкажыце на геапазіцыю: /remind_at тэкст",
+
+	"greet":          "З вяртаннем!\nМожа, патрэбныя налады?",
+	"greet.settings": "⚙ Налады",
+
+	"menu.main":           "Чым хочаце скарыстацца?",
+	"menu.weather":        "Даведацца надвор'е",
+	"menu.expenses":       "Паглядзець выдаткі",
+	"menu.currency":       "Даведацца курс валют",
+	"menu.settings":       "⚙️ Налады",
+	"menu.weather_title":  "Выберыце прамежак",
+	"menu.weather_today":  "Надвор'е на дзень",
+	"menu.weather_now":    "Надвор'е зараз",
+	"menu.air":            "Якасць паветра",
+	"menu.sun":            "Сонца і Месяц",
+	"menu.currency_title": "Выберыце курс:",
+	"menu.settings_title": "Налады",
+	"menu.send_location":  "📍 Адправіць месцазнаходжанне",
+	"menu.brief":          "🌅 Ранішняя зводка",
+	"menu.advice":         "💡 Парады па надвор'і",
+	"menu.calendar":       "📅 Каляндар",
+	"menu.all_settings":   "🛠 Усе налады",
+	"menu.home":           "🏠 Галоўнае меню",
+	"menu.back":           "⬅️ Назад: ",
+
+	"rates.failed":         "Не ўдалося атрымаць курс. Паспрабуйце пазней.",
+	"rates.line":           "Бягучы курс %s",
+	"inline.rate_desc":     "Афіцыйны курс Нацбанка Беларусі",
+	"inline.weather_title": "🌡 Надвор'е: %s",
+	"inline.my_place":      "ваша месца",
+
+	"settings.metric":          "Метрычныя (°C, м/с)",
+	"settings.imperial":        "Імперскія (°F, міль/г)",
+	"settings.lang":            "🌐 Мова",
+	"settings.lang_prompt":     "Выберыце мову інтэрфейсу.",
+	"settings.tz":              "🕒 Гадзінны пояс",
+	"settings.tz_prompt":       "Выберыце гадзінны пояс.",
+	"settings.units":           "📏 Адзінкі",
+	"settings.units_prompt":    "Выберыце адзінкі вымярэння.",
+	"settings.currency":        "💱 Базавая валюта",
+	"settings.currency_prompt": "Выберыце валюту, у якой паказваць курсы.",
+	"settings.brief":           "🌅 Ранішняя зводка",
+	"settings.brief_prompt":    "Выберыце час ранішняй зводкі.",
+	"settings.brief_disable":   "Выключыць",
+	"settings.brief_off":       "выключана",
+	"settings.brief_at":        "у %s",
+	"settings.loc":             "📍 Месцазнаходжанне",
+	"settings.loc_prompt":      "Выберыце месцазнаходжанне для надвор'я.\nКаб задаць сваё месца, адпраўце геапазіцыю ў чат.",
+	"settings.loc_reset":       "Скінуць на месца па змаўчанні",
+	"settings.loc_default":     "па змаўчанні",
+	"settings.text":            "⚙️ Налады чата\n\n🌐 Мова: %s\n🕒 Гадзінны пояс: %s\n📏 Адзінкі: %s\n💱 Базавая валюта: %s\n🌅 Ранішняя зводка: %s\n📍 Месцазнаходжанне: %s",
+	"settings.close":           "✖️ Закрыць",
+	"settings.closed":          "Налады закрыты.",
+	"settings.back":            "⬅️ Назад",
+	"settings.bad_option":      "Такога варыянта няма",
+	"settings.saved":           "Захавана",
+
+	"picker.other_hour": "⬅️ Іншая гадзіна",
+	"picker.no_dialog":  "Не ўдалося атрымаць дыялог, паспрабуйце пазней",
+	"picker.stale":      "Гэты выбар ужо неактуальны",
+	"picker.not_yours":  "Выбіраць можа толькі той, хто пачаў дыялог",
+
+	"flow.start_failed":      "Не ўдалося пачаць дыялог. Паспрабуйце пазней.",
+	"flow.save_failed":       "Не ўдалося захаваць адказ. Паспрабуйце пазней.",
+	"flow.nothing_to_cancel": "Няма чаго адмяняць.",
+	"flow.cancelled":         "Адменена.",
+
+	"expense.ask_amount":   "💸 Колькі выдаткавалі? Сума ў BYN, напрыклад 12.50\nАдмена: /cancel",
+	"expense.bad_amount":   "Патрэбна дадатная сума, напрыклад 12.50",
+	"expense.ask_category": "🏷 Катэгорыя? Выберыце або напішыце сваю.",
+	"expense.categories":   "Ежа|Транспарт|Дом|Здароўе|Забавы|Іншае",
+	"expense.bad_category": "Катэгорыя — ад 1 да %d сімвалаў.",
+	"expense.ask_date":     "📅 Калі выдаткавалі? Выберыце дзень або напішыце «сёння» ці «учора».",
+	"expense.bad_date":     "Патрэбны сённяшні або мінулы дзень, напрыклад: сёння, учора, 2025-06-20 або 20.06",
+	"expense.save_failed":  "Не ўдалося захаваць выдатак. Паспрабуйце пазней.",
+	"expense.saved":        "Запісаў: %s — %s, %s\nЗа месяц: /expenses",
+	"expense.none":         "У гэтым месяцы выдаткаў няма. Запісаць: /expense",
+	"expense.since":        "💰 Выдаткі з %s:\n",
+	"expense.total":        "\nУсяго: %s\nЗапісаць выдатак: /expense",
+
+	"remind.ask_date":           "📅 На які дзень? Выберыце ў календары або напішыце дату (заўтра, 20.06).\nАдмена: /cancel",
+	"remind.bad_date":           "Не зразумеў дату. Прыклад: заўтра, 2025-06-20 або 20.06",
+	"remind.past_date":          "Гэты дзень ужо мінуў. Выберыце іншы.",
+	"remind.ask_time_text":      "🕒 А якой гадзіне? Напрыклад, 9:00 або 18:30",
+	"remind.ask_time":           "🕒 А якой гадзіне? Выберыце гадзіну і хвіліны або напішыце час (9:00, 18:30).",
+	"remind.bad_time":           "Не зразумеў час. Прыклад: 9:00 або 18:30",
+	"remind.past_time":          "Гэты час ужо мінуў. Укажыце час у будучыні.",
+	"remind.ask_text":           "✏️ Пра што нагадаць?",
+	"remind.empty_text":         "Напішыце тэкст напаміну.",
+	"remind.broken_dialog":      "Дыялог пашкоджаны, пачніце нанова: /remind",
+	"remind.save_failed":        "Не ўдалося захаваць напамін. Паспрабуйце пазней.",
+	"remind.set":                "Напамін усталяваны на %s",
+	"remind.quote":              ":\n«%s»",
+	"remind.usage":              "Фармат: /remind [@карыстальнік] [асабіста] калі тэкст\nМожна адказаць камандай на паведамленне: /remind праз 2 гадзіны — тэкст тады не абавязковы\nПрыклады:\n/remind 2025-06-20 15:30 Купіць кветкі\n/remind заўтра 10:00 Патэлефанаваць маме\n/remind праз 2 гадзіны Выключыць духоўку\n/remind @ivan заўтра 10:00 рэўю",
+	"remind.mention_private":    "Згадваць іншых можна толькі ў групе. Каб нагадаць сабе, не ўказвайце @карыстальніка.",
+	"remind.foreign_limit":      "У вас ужо %d актыўны напамін для іншых. Пачакайце, пакуль ён спрацуе.|У вас ужо %d актыўныя напаміны для іншых. Пачакайце, пакуль яны спрацуюць.|У вас ужо %d актыўных напамінаў для іншых. Пачакайце, пакуль яны спрацуюць.",
+	"remind.for":                " для %s",
+	"remind.for_private":        " для @%s (у асабістыя паведамленні)",
+	"remind.to_private":         " (у асабістыя паведамленні)",
+	"remind.private_only_group": "Адправіць напамін іншаму чалавеку асабіста можна толькі з агульнай групы.",
+	"remind.unseen_user":        "Не ведаю @%s: хай ён спачатку напіша боту — у гэтай групе ці асабіста.",
+	"remind.unknown_user":       "@%s яшчэ не запускаў бота, таму напамін можна адправіць толькі ў гэтую групу (без «асабіста»).",
+	"remind.not_member":         "@%s не ўваходзіць у гэтую групу.",
+	"remind.see_message":        "гл. паведамленне",
+	"remind.delivered_for":      "⌛ Напамін для %s: %s",
+	"remind.delivered":          "⌛ Напамін: %s",
+
+	"repeat.sunset":  " (кожны дзень, %s)",
+	"repeat.daily":   " (кожны дзень)",
+	"repeat.weekly":  " (кожны тыдзень)",
+	"repeat.monthly": " (кожны месяц)",
+	"repeat.yearly":  " (кожны год)",
+
+	"reminders.cleared":      "Выдалены %d недастаўлены напамін|Выдалены %d недастаўленыя напаміны|Выдалена %d недастаўленых напамінаў",
+	"reminders.none":         "Напамінаў няма. Стварыць: /remind YYYY-MM-DD HH:MM тэкст",
+	"reminders.reason":       "\n  прычына: %s\n",
+	"reminders.retrying":     " (паўторная адпраўка, %d спроба)| (паўторная адпраўка, %d спробы)| (паўторная адпраўка, %d спроб)",
+	"reminders.place":        "• %.5f, %.5f (%d м) — %s\n",
+	"reminders.place_delete": "🗑 %s",
+	"reminders.pending":      "⏰ Запланаваныя напаміны:\n",
+	"reminders.places":       "\n📍 Напаміны па месцы:\n",
+	"reminders.groups":       "\n👥 Вашы напаміны ў групах:\n",
+	"reminders.group_title":  " · %s",
+	"reminders.dead":         "\n❌ Не ўдалося даставіць:\n",
+	"reminders.clear_hint":   "\nАчысціць спіс: /reminders clear",
+
+	"ics.export_usage":      "Фармат: /export reminders — выгрузіць напаміны ў файл .ics для календара",
+	"ics.export_empty":      "Напамінаў няма — выгружаць няма чаго.",
+	"ics.export_failed":     "Не ўдалося сфармаваць файл. Паспрабуйце пазней.",
+	"ics.export_caption":    "📅 %d напамін. Файл можна імпартаваць у любы каляндар.|📅 %d напаміны. Файл можна імпартаваць у любы каляндар.|📅 %d напамінаў. Файл можна імпартаваць у любы каляндар.",
+	"ics.nothing_to_import": "У календары няма будучых падзей — імпартаваць няма чаго.",
+	"ics.already_imported":  "Усе будучыя падзеі гэтага календара ўжо імпартаваны.",
+	"ics.preview":           "📅 Будзе створаны %d напамін\n|📅 Будзе створана %d напаміны\n|📅 Будзе створана %d напамінаў\n",
+	"ics.preview_more":      "… і яшчэ %d\n",
+	"ics.import_btn":        "✅ Імпартаваць",
+	"ics.cancel_btn":        "✖️ Адмена",
+	"ics.file_not_found":    "Файл для імпарту не знойдзены",
+	"ics.not_yours":         "Пацвердзіць імпарт можа толькі той, хто даслаў файл",
+	"ics.imported":          "✅ Імпартаваны %d напамін|✅ Імпартавана %d напаміны|✅ Імпартавана %d напамінаў",
+	"ics.import_failed":     "\nНе ўдалося захаваць: %d",
+	"ics.list_hint":         "\nСпіс: /reminders",
+	"ics.import_cancelled":  "Імпарт адменены.",
+	"ics.too_big":           "Файл занадта вялікі: каляндар павінен быць не больш за %d КБ.",
+	"ics.download_failed":   "Не ўдалося спампаваць файл. Паспрабуйце пазней.",
+	"ics.parse_failed":      "Не ўдалося разабраць каляндар: %v",
+	"ics.untitled":          "Падзея з календара",
+	"ics.note_past":         "прапушчана мінулых падзей: %d",
+	"ics.note_duplicates":   "ужо імпартаваны раней: %d",
+	"ics.note_unsupported":  "складанае правіла паўтору ў %d падзеі — яна спрацуе адзін раз|складанае правіла паўтору ў %d падзей — яны спрацуюць адзін раз|складанае правіла паўтору ў %d падзей — яны спрацуюць адзін раз",
+	"ics.note_too_many":     "не змясціліся ў ліміт %d: %d",
+
+	"caldav.usage":             "Сінхранізацыя напамінаў з календаром CalDAV (Nextcloud, Radicale і інш.):\n/caldav адрас_календара лагін пароль — падключыць (толькі ў асабістым чаце)\n/caldav sync — сінхранізаваць зараз\n/caldav off — адключыць\nПрыклад адраса: https://cloud.example.com/remote.php/dav/calendars/ivan/personal/\nВыкарыстоўвайце пароль праграмы, а не асноўны пароль.",
+	"caldav.result":            "з календара: новых %d, зменена %d, выдалена %d; у каляндар: новых %d, зменена %d, выдалена %d",
+	"caldav.result_skipped":    "\n⚠️ Не ўдалося прачытаць аб'ектаў календара: %d",
+	"caldav.kept":              "змены з календара не ўжытыя, напаміны пакінутыя як былі: %s",
+	"caldav.kept_rrule":        "«%s» — правіла паўтору %s не падтрымліваецца",
+	"caldav.kept_past":         "«%s» — падзея перанесена ў мінулае",
+	"caldav.sync_failed":       "Не ўдалося сінхранізаваць каляндар: %v",
+	"caldav.synced":            "🔄 Сінхранізавана — %s",
+	"caldav.disconnected":      "Каляндар адключаны. Напаміны засталіся ў боце і ў календары.",
+	"caldav.private_only":      "Падключаць каляндар можна толькі ў асабістым чаце з ботам — там не відаць пароля.",
+	"caldav.open_failed":       "Не ўдалося адкрыць каляндар: %v\nПраверце адрас, лагін і пароль.",
+	"caldav.first_sync_failed": "📅 Каляндар падключаны, але першая сінхранізацыя не ўдалася: %v",
+	"caldav.connected":         "📅 Каляндар падключаны, сінхранізацыя %s.\n%s",
+	"caldav.status":            "📅 Каляндар: %s (%s)\n",
+	"caldav.never_synced":      "Яшчэ не сінхранізаваўся\n",
+	"caldav.last_sync":         "Апошняя сінхранізацыя: %s\n",
+	"caldav.last_error":        "⚠️ Памылка: %s\n",
+	"caldav.status_hint":       "\n/caldav sync — сінхранізаваць зараз, /caldav off — адключыць",
+
+	"plural.minutes_every": "кожную %d хвіліну|кожныя %d хвіліны|кожныя %d хвілін",
+	"plural.minutes":       "%d хвіліну|%d хвіліны|%d хвілін",
+	"plural.days":          "%d дзень|%d дні|%d дзён",
+
+	"settings.save_failed": "Не ўдалося захаваць налады. Паспрабуйце пазней.",
+	"settings.load_failed": "Не ўдалося атрымаць налады. Паспрабуйце пазней.",
+}
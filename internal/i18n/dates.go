@@ -0,0 +1,72 @@
+package i18n
+
+import (
+	"fmt"
+	"time"
+)
+
+// dateNames — названия дней недели и месяцев одного языка
+type dateNames struct {
+	days       [7]string  // с воскресенья, как time.Weekday
+	shortDays  [7]string  // сокращения для сетки календаря
+	months     [12]string // в родительном падеже: «20 июня»
+	monthTitle [12]string // в именительном падеже: «Июнь 2025»
+	dayMonth   string     // порядок дня и месяца: «20 июня» или «June 20»
+}
+
+var names = map[string]dateNames{
+	RU: {
+		days:       [7]string{"Воскресенье", "Понедельник", "Вторник", "Среда", "Четверг", "Пятница", "Суббота"},
+		shortDays:  [7]string{"Вс", "Пн", "Вт", "Ср", "Чт", "Пт", "Сб"},
+		months:     [12]string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"},
+		monthTitle: [12]string{"Январь", "Февраль", "Март", "Апрель", "Май", "Июнь", "Июль", "Август", "Сентябрь", "Октябрь", "Ноябрь", "Декабрь"},
+		dayMonth:   "%[1]d %[2]s",
+	},
+	EN: {
+		days:       [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		shortDays:  [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+		months:     [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		monthTitle: [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		dayMonth:   "%[2]s %[1]d",
+	},
+	BE: {
+		days:       [7]string{"Нядзеля", "Панядзелак", "Аўторак", "Серада", "Чацвер", "Пятніца", "Субота"},
+		shortDays:  [7]string{"Нд", "Пн", "Аў", "Ср", "Чц", "Пт", "Сб"},
+		months:     [12]string{"студзеня", "лютага", "сакавіка", "красавіка", "мая", "чэрвеня", "ліпеня", "жніўня", "верасня", "кастрычніка", "лістапада", "снежня"},
+		monthTitle: [12]string{"Студзень", "Люты", "Сакавік", "Красавік", "Май", "Чэрвень", "Ліпень", "Жнівень", "Верасень", "Кастрычнік", "Лістапад", "Снежань"},
+		dayMonth:   "%[1]d %[2]s",
+	},
+}
+
+func namesOf(lang string) dateNames {
+	if n, ok := names[lang]; ok {
+		return n
+	}
+	return names[Default]
+}
+
+// DayName возвращает название дня недели: «Пятница», «Friday»
+func DayName(lang string, t time.Time) string {
+	return namesOf(lang).days[t.Weekday()]
+}
+
+// ShortDayName возвращает двухбуквенное сокращение дня недели — для сетки календаря
+func ShortDayName(lang string, t time.Time) string {
+	return namesOf(lang).shortDays[t.Weekday()]
+}
+
+// MonthName возвращает название месяца в том виде, в каком оно стоит рядом с числом:
+// «июня» по-русски, «June» по-английски
+func MonthName(lang string, t time.Time) string {
+	return namesOf(lang).months[t.Month()-1]
+}
+
+// MonthTitle возвращает название месяца в именительном падеже — для заголовков
+func MonthTitle(lang string, t time.Time) string {
+	return namesOf(lang).monthTitle[t.Month()-1]
+}
+
+// DayMonth форматирует число и месяц в порядке языка: «20 июня», «June 20»
+func DayMonth(lang string, t time.Time) string {
+	return fmt.Sprintf(namesOf(lang).dayMonth, t.Day(), MonthName(lang, t))
+}
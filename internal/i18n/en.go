@@ -0,0 +1,333 @@
+package i18n
+
+// en — сообщения на английском языке
+var en = Catalog{
+	"cmd.start":              "Main menu",
+	"cmd.start.help":         "main menu with buttons: weather, air quality, sun and moon,\nexpenses, exchange rates and settings",
+	"cmd.help":               "List of commands",
+	"cmd.help.help":          "list of commands available in this chat",
+	"cmd.remind.args":        "[@user] [private] when text",
+	"cmd.remind":             "Set a reminder",
+	"cmd.remind.help":        "set a reminder. “When”: 2025-06-20 15:30, tomorrow 10:00, 15:30, in 2 hours\n(example: /remind tomorrow 10:00 Buy flowers); without arguments — step by step\n— in a group you can remind a member: /remind @ivan tomorrow 10:00 review;\nwith the word “private” the reminder goes to their private messages",
+	"cmd.reminders.args":     "[clear]",
+	"cmd.reminders":          "List reminders",
+	"cmd.reminders.help":     "list reminders, including undelivered ones (clear — clear undelivered)\nIn a group — the chat's shared reminders, in a private chat — also your reminders from groups\nLocation reminders are deleted with the buttons below the list",
+	"cmd.remind_sunset.args": "±minutes text",
+	"cmd.remind_sunset":      "Daily reminder relative to sunset",
+	"cmd.remind_sunset.help": "daily reminder relative to sunset (example: /remind_sunset -30 Close the greenhouse)",
+	"cmd.remind_at.args":     "[radius_m] text",
+	"cmd.remind_at":          "Location reminder",
+	"cmd.remind_at.help":     "location reminder: reply to a location; fires when you are nearby\nand share your live location (example: /remind_at 300 Buy milk)",
+	"cmd.export.args":        "reminders",
+	"cmd.export":             "Export reminders to an .ics file",
+	"cmd.export.help":        "export reminders to an .ics file; send an .ics file to import events",
+	"cmd.caldav.args":        "[url login password | sync | off]",
+	"cmd.caldav":             "Sync with a CalDAV calendar",
+	"cmd.caldav.help":        "sync reminders with a CalDAV calendar (Nextcloud, Radicale)",
+	"cmd.expense":            "Record an expense",
+	"cmd.expense.help":       "record an expense: amount, category, day",
+	"cmd.expenses":           "Expenses for the month",
+	"cmd.expenses.help":      "expenses for the current month by category",
+	"cmd.cancel":             "Stop a step-by-step dialog",
+	"cmd.cancel.help":        "stop a step-by-step dialog",
+	"cmd.settings":           "Chat settings",
+	"cmd.settings.help":      "language, time zone, units, base currency, brief time and location",
+	"cmd.subscribe":          "Subscribe to the morning brief",
+	"cmd.subscribe.help":     "subscribe to the daily morning brief (time and time zone — in /settings)",
+	"cmd.unsubscribe":        "Unsubscribe from the morning brief",
+	"cmd.unsubscribe.help":   "unsubscribe from the morning brief",
+	"cmd.brief_air.args":     "on|off",
+	"cmd.brief_air":          "Air quality in the morning brief",
+	"cmd.brief_air.help":     "add air quality to the morning brief",
+	"cmd.air_alert.args":     "1-4|off",
+	"cmd.air_alert":          "Air quality alerts",
+	"cmd.air_alert.help":     "notify when the air quality index goes above the threshold",
+	"cmd.advice.args":        "[umbrella|sunscreen|cold|heat|wind value | reset]",
+	"cmd.advice":             "Weather tip thresholds",
+	"cmd.advice.help":        "view or change weather tip thresholds",
+	"help.footer":            "Send a location to get weather and air quality for your place\n\nIn a group the bot answers commands and mentions of @%[1]s\n\nIn any chat: @%[1]s usd, @%[1]s 100 eur, @%[1]s weather Minsk",
+	"help.admins_only":       "🔒 in a group — admins only",
+	"group.admins_only":      "🔒 Only group admins can change the group settings.",
+	"group.untitled":         "untitled group",
+
+	"unit.ms":             "m/s",
+	"unit.mph":            "mph",
+	"unit.mm":             "mm",
+	"unit.in":             "in",
+	"unit.hpa":            "hPa",
+	"unit.inhg":           "inHg",
+	"compass":             "N|NE|E|SE|S|SW|W|NW",
+	"date.yesterday":      "yesterday at %s",
+	"date.today":          "today at %s",
+	"date.tomorrow":       "tomorrow at %s",
+	"date.after_tomorrow": "the day after tomorrow at %s",
+	"date.at":             "%s at %s",
+
+	"weather.nodata":    "no data",
+	"weather.now":       "🌡 Now (%s): %s, %s\nFeels like %s\n",
+	"weather.wind":      "🌬️ Wind: %s",
+	"weather.gusts":     " (gusts up to %s)",
+	"weather.humidity":  "💧 Humidity: %.0f%%\n",
+	"weather.rain_1h":   "🌧️ Rain (last hour): %s\n",
+	"weather.snow_1h":   "❄️ Snow (last hour): %s\n",
+	"weather.day_title": "☀️ Weather for %s:\n\n",
+	"weather.day_temp":  "🌡 Temperature: from %s to %s\n• morning %s (feels like %s)\n• day %s (feels like %s)\n• evening %s (feels like %s)\n• night %s (feels like %s)\n\n",
+	"weather.pop":       "☔ Chance of precipitation: %.0f%%\n",
+	"weather.rain":      "🌧️ Rain: %s\n",
+	"weather.snow":      "❄️ Snow: %s\n",
+	"weather.wind_max":  "🌬️ Wind: up to %s\n",
+	"weather.pressure":  "📊 Pressure: %s\n",
+	"weather.uvi":       "☀️ UV index: %s\n",
+	"weather.no_daily":  "The weather response has no daily forecast",
+
+	"sun.report":       "🌅 Sun and Moon, %s:\n\n☀️ Sunrise: %s\n🌇 Sunset: %s\n⏳ Day length: %d h %02d min\n\n🌙 Moonrise: %s\n🌙 Moonset: %s\nPhase: %s\n",
+	"sun.report_polar": "🌅 Sun and Moon, %s:\n\n%s\n\n🌙 Moonrise: %s\n🌙 Moonset: %s\nPhase: %s\n",
+	"sun.polar_day":    "☀️ Polar day: the sun doesn't set",
+	"sun.polar_night":  "🌑 Polar night: the sun doesn't rise",
+
+	"moon.new":             "🌑 new moon",
+	"moon.waxing_crescent": "🌒 waxing crescent",
+	"moon.first_quarter":   "🌓 first quarter",
+	"moon.waxing_gibbous":  "🌔 waxing gibbous",
+	"moon.full":            "🌕 full moon",
+	"moon.waning_gibbous":  "🌖 waning gibbous",
+	"moon.last_quarter":    "🌗 last quarter",
+	"moon.waning_crescent": "🌘 waning crescent",
+
+	"sunset.before":     "%s before sunset",
+	"sunset.after":      "%s after sunset",
+	"sunset.at":         "at sunset",
+	"sunset.usage":      "Give an offset in minutes and a text. Example:\n/remind_sunset -30 Close the greenhouse",
+	"sunset.bad_offset": "The offset is a whole number of minutes from -720 to 720. Negative means before sunset.",
+	"sunset.failed":     "Could not get the sunset time. Try again later.",
+	"sunset.saved":      "I'll remind you every day (%s). Next one — %s:\n“%s”",
+
+	"brief.greeting":           "🌞 Good morning! Today is %s, %s\n\n",
+	"brief.weather":            "🌡 Weather: %s, %s (feels like %s)\n",
+	"brief.reminders":          "⏰ Today's reminders:\n",
+	"brief.on":                 "🌅 Morning brief is on: every day at %s.",
+	"brief.off":                "Morning brief is off.",
+	"brief.subscribe_failed":   "Could not subscribe. Try again later.",
+	"brief.subscribed":         "You are subscribed to the morning brief (%s %s). Change the time: /settings",
+	"brief.unsubscribe_failed": "Could not unsubscribe. Try again later.",
+	"brief.unsubscribed":       "You are unsubscribed from the morning brief.",
+	"brief.air_usage":          "Use: /brief_air on or /brief_air off",
+	"brief.air_on":             "Air quality will be added to the morning brief.",
+	"brief.air_off":            "Air quality will no longer be added to the morning brief.",
+
+	"air.aqi1":        "good",
+	"air.aqi2":        "fair",
+	"air.aqi3":        "moderate",
+	"air.aqi4":        "poor",
+	"air.aqi5":        "very poor",
+	"air.report":      "🌫 Air quality: %s (AQI %d of 5)\n• PM2.5: %s µg/m³\n• PM10: %s µg/m³\n• O₃: %s µg/m³\n",
+	"air.alert_usage": "Give a threshold from 1 to 4 or off. Example:\n/air_alert 3 — notify when AQI goes above 3",
+	"air.alert_off":   "Air quality alerts are off.",
+	"air.alert_on":    "I'll let you know when the air quality index goes above %d (%s).",
+	"air.alert":       "⚠️ Air quality has worsened!\n\n",
+
+	"advice.title":           "\n💡 Tips:\n",
+	"advice.umbrella":        "☂️ Take an umbrella",
+	"advice.sunscreen":       "🧴 You'll need sunscreen",
+	"advice.ice":             "🧊 Possible ice — be careful on the roads",
+	"advice.cold":            "🧣 Dress warmly: hat and gloves",
+	"advice.heat":            "🥤 It's hot — take water and a hat",
+	"advice.wind":            "🌬️ Strong wind — an umbrella may not survive the walk",
+	"advice.reset":           "Tip thresholds have been reset to defaults.",
+	"advice.not_number":      "The value must be a number. Example: /advice umbrella 50",
+	"advice.out_of_range":    "The %s value must be between %s and %s.",
+	"advice.cold_above_heat": "The cold threshold must be below the heat threshold: now cold is %s and heat is %s.",
+	"advice.unknown_rule":    "Unknown rule. Available: umbrella, sunscreen, cold, heat, wind.",
+	"advice.usage":           "Use: /advice, /advice <rule> <value> or /advice reset",
+	"advice.thresholds":      "Tip thresholds:\n• umbrella — umbrella at a chance of precipitation from %.0f%%\n• sunscreen — sunscreen at a UV index from %.1f\n• cold — dress warmly when it feels like %.1f°C or below\n• heat — heat when it feels like %.1f°C or above\n• wind — strong wind from %.1f m/s\n\nChange: /advice umbrella 50, reset: /advice reset",
+
+	"geo.usage":         "A location reminder fires when you share your live location near the point.\nReply with the command to a message with a location:\n/remind_at [radius_m] text\nor give coordinates:\n/remind_at 55.139 27.684 [radius_m] text\nExample: /remind_at 300 Buy milk",
+	"geo.bad_coords":    "coordinates are out of range",
+	"geo.bad_radius":    "the radius must be from %d to %d meters",
+	"geo.no_text":       "the reminder text is missing",
+	"geo.saved":         "📍 I'll remind you “%s” when you are within %d m of the point.\nThe reminder works with live location sharing (📎 → Location → Share live location).",
+	"geo.delivered_for": "📍 Reminder for @%s: %s",
+	"geo.delivered":     "📍 Reminder: %s",
+	"geo.deleted":       "🗑 Location reminder deleted",
+	"geo.not_found":     "The reminder is already deleted or has fired",
+	"geo.not_yours":     "Only the author can delete this reminder",
+	"geo.delete_failed": "Couldn't delete the reminder. Try again later.",
+
+	"location.save_failed": "Could not save the location. Try again later.",
+	"location.saved":       "📍 Location saved, weather and air quality will be shown for it.\nTo get a reminder when you are here, reply to the location: /remind_at text",
+
+	"greet":          "Welcome back!\nNeed the settings?",
+	"greet.settings": "⚙ Settings",
+
+	"menu.main":           "What would you like to do?",
+	"menu.weather":        "Weather",
+	"menu.expenses":       "Expenses",
+	"menu.currency":       "Exchange rates",
+	"menu.settings":       "⚙️ Settings",
+	"menu.weather_title":  "Choose a period",
+	"menu.weather_today":  "Today's forecast",
+	"menu.weather_now":    "Current weather",
+	"menu.air":            "Air quality",
+	"menu.sun":            "Sun and Moon",
+	"menu.currency_title": "Choose a rate:",
+	"menu.settings_title": "Settings",
+	"menu.send_location":  "📍 Send location",
+	"menu.brief":          "🌅 Morning brief",
+	"menu.advice":         "💡 Weather tips",
+	"menu.calendar":       "📅 Calendar",
+	"menu.all_settings":   "🛠 All settings",
+	"menu.home":           "🏠 Main menu",
+	"menu.back":           "⬅️ Back: ",
+
+	"rates.failed":         "Could not get the rate. Try again later.",
+	"rates.line":           "Current rate %s",
+	"inline.rate_desc":     "Official rate of the National Bank of Belarus",
+	"inline.weather_title": "🌡 Weather: %s",
+	"inline.my_place":      "your place",
+
+	"settings.metric":          "Metric (°C, m/s)",
+	"settings.imperial":        "Imperial (°F, mph)",
+	"settings.lang":            "🌐 Language",
+	"settings.lang_prompt":     "Choose the interface language.",
+	"settings.tz":              "🕒 Time zone",
+	"settings.tz_prompt":       "Choose a time zone.",
+	"settings.units":           "📏 Units",
+	"settings.units_prompt":    "Choose measurement units.",
+	"settings.currency":        "💱 Base currency",
+	"settings.currency_prompt": "Choose the currency to show rates in.",
+	"settings.brief":           "🌅 Morning brief",
+	"settings.brief_prompt":    "Choose the morning brief time.",
+	"settings.brief_disable":   "Turn off",
+	"settings.brief_off":       "off",
+	"settings.brief_at":        "at %s",
+	"settings.loc":             "📍 Location",
+	"settings.loc_prompt":      "Choose the location for the weather.\nTo set your own place, send a location to the chat.",
+	"settings.loc_reset":       "Reset to the default place",
+	"settings.loc_default":     "default",
+	"settings.text":            "⚙️ Chat settings\n\n🌐 Language: %s\n🕒 Time zone: %s\n📏 Units: %s\n💱 Base currency: %s\n🌅 Morning brief: %s\n📍 Location: %s",
+	"settings.close":           "✖️ Close",
+	"settings.closed":          "Settings closed.",
+	"settings.back":            "⬅️ Back",
+	"settings.bad_option":      "No such option",
+	"settings.saved":           "Saved",
+
+	"picker.other_hour": "⬅️ Another hour",
+	"picker.no_dialog":  "Could not load the dialog, try again later",
+	"picker.stale":      "This choice is no longer relevant",
+	"picker.not_yours":  "Only the person who started the dialog can choose",
+
+	"flow.start_failed":      "Could not start the dialog. Try again later.",
+	"flow.save_failed":       "Could not save the answer. Try again later.",
+	"flow.nothing_to_cancel": "Nothing to cancel.",
+	"flow.cancelled":         "Cancelled.",
+
+	"expense.ask_amount":   "💸 How much did you spend? Amount in BYN, e.g. 12.50\nCancel: /cancel",
+	"expense.bad_amount":   "Please enter a positive amount, e.g. 12.50",
+	"expense.ask_category": "🏷 Category? Pick one or type your own.",
+	"expense.categories":   "Food|Transport|Home|Health|Entertainment|Other",
+	"expense.bad_category": "A category must be 1 to %d characters long.",
+	"expense.ask_date":     "📅 When did you spend it? Pick a day or type “today” or “yesterday”.",
+	"expense.bad_date":     "Please enter today or a past day, e.g. today, yesterday, 2025-06-20 or 20.06",
+	"expense.save_failed":  "Could not save the expense. Try again later.",
+	"expense.saved":        "Saved: %s — %s, %s\nThis month: /expenses",
+	"expense.none":         "No expenses this month. Add one: /expense",
+	"expense.since":        "💰 Expenses since %s:\n",
+	"expense.total":        "\nTotal: %s\nAdd an expense: /expense",
+
+	"remind.ask_date":           "📅 Which day? Pick it in the calendar or type a date (tomorrow, 20.06).\nCancel: /cancel",
+	"remind.bad_date":           "I didn't get the date. Example: tomorrow, 2025-06-20 or 20.06",
+	"remind.past_date":          "This day has already passed. Pick another one.",
+	"remind.ask_time_text":      "🕒 What time? For example, 9:00 or 18:30",
+	"remind.ask_time":           "🕒 What time? Pick the hour and minutes or type the time (9:00, 18:30).",
+	"remind.bad_time":           "I didn't get the time. Example: 9:00 or 18:30",
+	"remind.past_time":          "This time has already passed. Please choose a time in the future.",
+	"remind.ask_text":           "✏️ What should I remind you about?",
+	"remind.empty_text":         "Please type the reminder text.",
+	"remind.broken_dialog":      "The dialog is broken, start over: /remind",
+	"remind.save_failed":        "Could not save the reminder. Try again later.",
+	"remind.set":                "Reminder set for %s",
+	"remind.quote":              ":\n“%s”",
+	"remind.usage":              "Format: /remind [@user] [private] when text\nYou can reply to a message with the command: /remind in 2 hours — the text is optional then\nExamples:\n/remind 2025-06-20 15:30 Buy flowers\n/remind tomorrow 10:00 Call mom\n/remind in 2 hours Turn off the oven\n/remind @ivan tomorrow 10:00 review",
+	"remind.mention_private":    "You can mention others only in a group. To remind yourself, leave out the @user.",
+	"remind.foreign_limit":      "You already have %d active reminder for others. Wait until it fires.|You already have %d active reminders for others. Wait until they fire.",
+	"remind.for":                " for %s",
+	"remind.for_private":        " for @%s (in private messages)",
+	"remind.to_private":         " (in private messages)",
+	"remind.private_only_group": "You can send a private reminder to another person only from a shared group.",
+	"remind.unseen_user":        "I don't know @%s yet: ask them to message the bot first, in this group or privately.",
+	"remind.unknown_user":       "@%s hasn't started the bot yet, so the reminder can only go to this group (without “private”).",
+	"remind.not_member":         "@%s is not a member of this group.",
+	"remind.see_message":        "see the message",
+	"remind.delivered_for":      "⌛ Reminder for %s: %s",
+	"remind.delivered":          "⌛ Reminder: %s",
+
+	"repeat.sunset":  " (every day, %s)",
+	"repeat.daily":   " (every day)",
+	"repeat.weekly":  " (every week)",
+	"repeat.monthly": " (every month)",
+	"repeat.yearly":  " (every year)",
+
+	"reminders.cleared":      "Removed %d undelivered reminder|Removed %d undelivered reminders",
+	"reminders.none":         "No reminders. Create one: /remind YYYY-MM-DD HH:MM text",
+	"reminders.reason":       "\n  reason: %s\n",
+	"reminders.retrying":     " (retrying, %d attempt)| (retrying, %d attempts)",
+	"reminders.place":        "• %.5f, %.5f (%d m) — %s\n",
+	"reminders.place_delete": "🗑 %s",
+	"reminders.pending":      "⏰ Scheduled reminders:\n",
+	"reminders.places":       "\n📍 Location reminders:\n",
+	"reminders.groups":       "\n👥 Your reminders in groups:\n",
+	"reminders.group_title":  " · %s",
+	"reminders.dead":         "\n❌ Could not deliver:\n",
+	"reminders.clear_hint":   "\nClear the list: /reminders clear",
+
+	"ics.export_usage":      "Format: /export reminders — export reminders to an .ics calendar file",
+	"ics.export_empty":      "No reminders — nothing to export.",
+	"ics.export_failed":     "Could not build the file. Try again later.",
+	"ics.export_caption":    "📅 %d reminder. The file can be imported into any calendar.|📅 %d reminders. The file can be imported into any calendar.",
+	"ics.nothing_to_import": "The calendar has no upcoming events — nothing to import.",
+	"ics.already_imported":  "All upcoming events from this calendar have already been imported.",
+	"ics.preview":           "📅 %d reminder will be created\n|📅 %d reminders will be created\n",
+	"ics.preview_more":      "… and %d more\n",
+	"ics.import_btn":        "✅ Import",
+	"ics.cancel_btn":        "✖️ Cancel",
+	"ics.file_not_found":    "The file to import was not found",
+	"ics.not_yours":         "Only the person who sent the file can confirm the import",
+	"ics.imported":          "✅ Imported %d reminder|✅ Imported %d reminders",
+	"ics.import_failed":     "\nCould not save: %d",
+	"ics.list_hint":         "\nList: /reminders",
+	"ics.import_cancelled":  "Import cancelled.",
+	"ics.too_big":           "The file is too big: the calendar must be at most %d KB.",
+	"ics.download_failed":   "Could not download the file. Try again later.",
+	"ics.parse_failed":      "Could not parse the calendar: %v",
+	"ics.untitled":          "Calendar event",
+	"ics.note_past":         "past events skipped: %d",
+	"ics.note_duplicates":   "already imported before: %d",
+	"ics.note_unsupported":  "%d event has a complex repeat rule — it will fire once|%d events have a complex repeat rule — they will fire once",
+	"ics.note_too_many":     "did not fit into the limit of %d: %d",
+
+	"caldav.usage":             "Sync reminders with a CalDAV calendar (Nextcloud, Radicale, etc.):\n/caldav calendar_url login password — connect (private chat only)\n/caldav sync — sync now\n/caldav off — disconnect\nExample URL: https://cloud.example.com/remote.php/dav/calendars/ivan/personal/\nUse an app password, not your main password.",
+	"caldav.result":            "from the calendar: %d new, %d changed, %d removed; to the calendar: %d new, %d changed, %d removed",
+	"caldav.result_skipped":    "\n⚠️ Calendar objects that could not be read: %d",
+	"caldav.kept":              "calendar changes not applied, reminders kept as they were: %s",
+	"caldav.kept_rrule":        "“%s” — repeat rule %s is not supported",
+	"caldav.kept_past":         "“%s” — the event was moved to the past",
+	"caldav.sync_failed":       "Could not sync the calendar: %v",
+	"caldav.synced":            "🔄 Synced — %s",
+	"caldav.disconnected":      "Calendar disconnected. Reminders stay both in the bot and in the calendar.",
+	"caldav.private_only":      "You can connect a calendar only in a private chat with the bot, where nobody else sees the password.",
+	"caldav.open_failed":       "Could not open the calendar: %v\nCheck the URL, login and password.",
+	"caldav.first_sync_failed": "📅 Calendar connected, but the first sync failed: %v",
+	"caldav.connected":         "📅 Calendar connected, syncing %s.\n%s",
+	"caldav.status":            "📅 Calendar: %s (%s)\n",
+	"caldav.never_synced":      "Not synced yet\n",
+	"caldav.last_sync":         "Last sync: %s\n",
+	"caldav.last_error":        "⚠️ Error: %s\n",
+	"caldav.status_hint":       "\n/caldav sync — sync now, /caldav off — disconnect",
+
+	"plural.minutes_every": "every %d minute|every %d minutes",
+	"plural.minutes":       "%d minute|%d minutes",
+	"plural.days":          "%d day|%d days",
+
+	"settings.save_failed": "Could not save the settings. Try again later.",
+	"settings.load_failed": "Could not load the settings. Try again later.",
+}
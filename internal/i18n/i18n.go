@@ -0,0 +1,97 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Языки интерфейса
+const (
+	RU = "ru"
+	EN = "en"
+	BE = "be"
+)
+
+// Default — язык, на котором написаны все сообщения; в него же уходят
+// ключи, которых нет в каталоге выбранного языка
+const Default = RU
+
+// Languages — поддерживаемые языки в порядке показа
+var Languages = []string{RU, EN, BE}
+
+// Catalog — сообщения одного языка по ключам. Значение — шаблон для fmt.Sprintf;
+// у сообщений с числом формы множественного числа разделены «|» (см. N).
+type Catalog map[string]string
+
+var catalogs = map[string]Catalog{
+	RU: ru,
+	EN: en,
+	BE: be,
+}
+
+// Normalize приводит код языка Telegram (en, en-US, be-BY) к поддерживаемому языку.
+// Для неподдерживаемых языков возвращает пустую строку.
+func Normalize(code string) string {
+	code = strings.ToLower(code)
+	if i := strings.IndexAny(code, "-_"); i > 0 {
+		code = code[:i]
+	}
+	if _, ok := catalogs[code]; ok {
+		return code
+	}
+	return ""
+}
+
+// lookup находит шаблон сообщения: сначала в каталоге языка, затем в языке по умолчанию
+func lookup(lang, key string) string {
+	if msg, ok := catalogs[lang][key]; ok {
+		return msg
+	}
+	if msg, ok := catalogs[Default][key]; ok {
+		return msg
+	}
+	return key
+}
+
+// T возвращает сообщение key на языке lang, подставляя аргументы
+func T(lang, key string, args ...any) string {
+	msg := lookup(lang, key)
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// N возвращает сообщение key в форме множественного числа для n.
+// Число подставляется первым аргументом: «%d день|%d дня|%d дней».
+func N(lang, key string, n int, args ...any) string {
+	forms := strings.Split(lookup(lang, key), "|")
+	form := forms[min(pluralForm(lang, n), len(forms)-1)]
+	return fmt.Sprintf(form, append([]any{n}, args...)...)
+}
+
+// pluralForm выбирает номер формы множественного числа.
+// Русский и белорусский: 0 — «1 день, 21 день», 1 — «2 дня, 34 дня», 2 — «5 дней, 11 дней».
+// Английский: 0 — «1 day», 1 — «2 days».
+func pluralForm(lang string, n int) int {
+	if n < 0 {
+		n = -n
+	}
+
+	switch lang {
+	case RU, BE:
+		switch {
+		case n%10 == 1 && n%100 != 11:
+			return 0
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return 1
+		default:
+			return 2
+		}
+	default:
+		if n == 1 {
+			return 0
+		}
+		return 1
+	}
+}
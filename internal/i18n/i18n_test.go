@@ -0,0 +1,57 @@
+package i18n
+
+import "testing"
+
+func TestPluralForm(t *testing.T) {
+	slavic := []struct {
+		n, want int
+	}{
+		{0, 2}, {1, 0}, {2, 1}, {4, 1}, {5, 2}, {10, 2},
+		{11, 2}, {12, 2}, {13, 2}, {14, 2}, {15, 2},
+		{21, 0}, {22, 1}, {24, 1}, {25, 2}, {100, 2},
+		{101, 0}, {111, 2}, {112, 2}, {114, 2}, {121, 0}, {122, 1},
+		{1011, 2}, {1021, 0}, {-1, 0}, {-22, 1}, {-11, 2},
+	}
+	for _, lang := range []string{RU, BE} {
+		for _, tt := range slavic {
+			if got := pluralForm(lang, tt.n); got != tt.want {
+				t.Errorf("pluralForm(%q, %d) = %d, ожидалось %d", lang, tt.n, got, tt.want)
+			}
+		}
+	}
+
+	english := []struct {
+		n, want int
+	}{
+		{0, 1}, {1, 0}, {2, 1}, {11, 1}, {21, 1}, {101, 1}, {-1, 0}, {-2, 1},
+	}
+	for _, tt := range english {
+		if got := pluralForm(EN, tt.n); got != tt.want {
+			t.Errorf("pluralForm(en, %d) = %d, ожидалось %d", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestN(t *testing.T) {
+	tests := []struct {
+		lang string
+		n    int
+		want string
+	}{
+		{RU, 1, "Удалено 1 недоставленное напоминание"},
+		{RU, 3, "Удалено 3 недоставленных напоминания"},
+		{RU, 11, "Удалено 11 недоставленных напоминаний"},
+		{RU, 21, "Удалено 21 недоставленное напоминание"},
+		{RU, 112, "Удалено 112 недоставленных напоминаний"},
+	}
+	for _, tt := range tests {
+		if got := N(tt.lang, "reminders.cleared", tt.n); got != tt.want {
+			t.Errorf("N(%q, %d) = %q, ожидалось %q", tt.lang, tt.n, got, tt.want)
+		}
+	}
+
+	// По-английски форм две: «1 … reminder» и «2 … reminders»
+	if got := N(EN, "reminders.cleared", 1); got == N(EN, "reminders.cleared", 2) {
+		t.Errorf("N(en) не различает 1 и 2: %q", got)
+	}
+}
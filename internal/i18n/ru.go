@@ -0,0 +1,333 @@
+package i18n
+
+// ru — сообщения на русском языке (язык по умолчанию: здесь есть все ключи)
+var ru = Catalog{
+	"cmd.start":              "Главное меню",
+	"cmd.start.help":         "главное меню с кнопками: погода, качество воздуха, солнце и луна,\nтраты, курсы валют и настройки",
+	"cmd.help":               "Список команд",
+	"cmd.help.help":          "список команд, доступных в этом чате",
+	"cmd.remind.args":        "[@пользователь] [лично] когда текст",
+	"cmd.remind":             "Установить напоминание",
+	"cmd.remind.help":        "установить напоминание. «Когда»: 2025-06-20 15:30, завтра 10:00, 15:30, через 2 часа\n(пример: /remind завтра 10:00 Купить цветы); без аргументов — пошагово\n— в группе можно напомнить участнику: /remind @ivan завтра 10:00 ревью;\nсо словом «лично» напоминание придёт ему в личные сообщения",
+	"cmd.reminders.args":     "[clear]",
+	"cmd.reminders":          "Список напоминаний",
+	"cmd.reminders.help":     "список напоминаний, включая недоставленные (clear — очистить недоставленные)\nВ группе — общие напоминания чата, в личном чате — ещё и ваши напоминания из групп\nНапоминания по месту удаляются кнопками под списком",
+	"cmd.remind_sunset.args": "±минуты текст",
+	"cmd.remind_sunset":      "Ежедневное напоминание относительно заката",
+	"cmd.remind_sunset.help": "ежедневное напоминание относительно заката (пример: /remind_sunset -30 Закрыть теплицу)",
+	"cmd.remind_at.args":     "[радиус_м] текст",
+	"cmd.remind_at":          "Напоминание по месту",
+	"cmd.remind_at.help":     "напоминание по месту: ответьте на геопозицию; сработает, когда вы окажетесь рядом\nи поделитесь трансляцией геопозиции (пример: /remind_at 300 Купить молоко)",
+	"cmd.export.args":        "reminders",
+	"cmd.export":             "Выгрузить напоминания в файл .ics",
+	"cmd.export.help":        "выгрузить напоминания в файл .ics; пришлите файл .ics, чтобы импортировать события",
+	"cmd.caldav.args":        "[адрес логин пароль | sync | off]",
+	"cmd.caldav":             "Синхронизация с календарём CalDAV",
+	"cmd.caldav.help":        "синхронизировать напоминания с календарём CalDAV (Nextcloud, Radicale)",
+	"cmd.expense":            "Записать трату",
+	"cmd.expense.help":       "записать трату: сумма, категория, день",
+	"cmd.expenses":           "Траты за месяц",
+	"cmd.expenses.help":      "траты за текущий месяц по категориям",
+	"cmd.cancel":             "Прервать пошаговый диалог",
+	"cmd.cancel.help":        "прервать пошаговый диалог",
+	"cmd.settings":           "Настройки чата",
+	"cmd.settings.help":      "язык, часовой пояс, единицы, базовая валюта, время сводки и местоположение",
+	"cmd.subscribe":          "Подписаться на утреннюю сводку",
+	"cmd.subscribe.help":     "подписаться на ежедневную утреннюю сводку (время и часовой пояс — в /settings)",
+	"cmd.unsubscribe":        "Отписаться от утренней сводки",
+	"cmd.unsubscribe.help":   "отписаться от утренней сводки",
+	"cmd.brief_air.args":     "on|off",
+	"cmd.brief_air":          "Качество воздуха в утренней сводке",
+	"cmd.brief_air.help":     "добавлять в утреннюю сводку качество воздуха",
+	"cmd.air_alert.args":     "1-4|off",
+	"cmd.air_alert":          "Оповещения о качестве воздуха",
+	"cmd.air_alert.help":     "сообщать, когда индекс качества воздуха станет выше порога",
+	"cmd.advice.args":        "[umbrella|sunscreen|cold|heat|wind значение | reset]",
+	"cmd.advice":             "Пороги советов по погоде",
+	"cmd.advice.help":        "посмотреть или изменить пороги советов по погоде",
+	"help.footer":            "Отправьте геопозицию, чтобы погода и качество воздуха показывались для вашего места\n\nВ группе бот отвечает на команды и упоминания @%[1]s\n\nВ любом чате: @%[1]s usd, @%[1]s 100 eur, @%[1]s погода Минск",
+	"help.admins_only":       "🔒 в группе — только администраторы",
+	"group.admins_only":      "🔒 Настройки группы могут менять только её администраторы.",
+	"group.untitled":         "группа без названия",
+
+	"unit.ms":             "м/с",
+	"unit.mph":            "миль/ч",
+	"unit.mm":             "мм",
+	"unit.in":             "дюйм.",
+	"unit.hpa":            "гПа",
+	"unit.inhg":           "дюйм. рт. ст.",
+	"compass":             "С|СВ|В|ЮВ|Ю|ЮЗ|З|СЗ",
+	"date.yesterday":      "вчера в %s",
+	"date.today":          "сегодня в %s",
+	"date.tomorrow":       "завтра в %s",
+	"date.after_tomorrow": "послезавтра в %s",
+	"date.at":             "%s в %s",
+
+	"weather.nodata":    "нет данных",
+	"weather.now":       "🌡 Сейчас (%s): %s, %s\nОщущается как %s\n",
+	"weather.wind":      "🌬️ Ветер: %s",
+	"weather.gusts":     " (порывы до %s)",
+	"weather.humidity":  "💧 Влажность: %.0f%%\n",
+	"weather.rain_1h":   "🌧️ Дождь (за час): %s\n",
+	"weather.snow_1h":   "❄️ Снег (за час): %s\n",
+	"weather.day_title": "☀️ Погода на %s:\n\n",
+	"weather.day_temp":  "🌡 Температура: от %s до %s\n• утром %s (ощущается как %s)\n• днём %s (ощущается как %s)\n• вечером %s (ощущается как %s)\n• ночью %s (ощущается как %s)\n\n",
+	"weather.pop":       "☔ Вероятность осадков: %.0f%%\n",
+	"weather.rain":      "🌧️ Дождь: %s\n",
+	"weather.snow":      "❄️ Снег: %s\n",
+	"weather.wind_max":  "🌬️ Ветер: до %s\n",
+	"weather.pressure":  "📊 Давление: %s\n",
+	"weather.uvi":       "☀️ УФ-индекс: %s\n",
+	"weather.no_daily":  "В ответе погоды нет прогноза на день",
+
+	"sun.report":       "🌅 Солнце и Луна, %s:\n\n☀️ Восход: %s\n🌇 Закат: %s\n⏳ Продолжительность дня: %d ч %02d мин\n\n🌙 Восход луны: %s\n🌙 Заход луны: %s\nФаза: %s\n",
+	"sun.report_polar": "🌅 Солнце и Луна, %s:\n\n%s\n\n🌙 Восход луны: %s\n🌙 Заход луны: %s\nФаза: %s\n",
+	"sun.polar_day":    "☀️ Полярный день: солнце не заходит",
+	"sun.polar_night":  "🌑 Полярная ночь: солнце не восходит",
+
+	"moon.new":             "🌑 новолуние",
+	"moon.waxing_crescent": "🌒 растущий серп",
+	"moon.first_quarter":   "🌓 первая четверть",
+	"moon.waxing_gibbous":  "🌔 растущая луна",
+	"moon.full":            "🌕 полнолуние",
+	"moon.waning_gibbous":  "🌖 убывающая луна",
+	"moon.last_quarter":    "🌗 последняя четверть",
+	"moon.waning_crescent": "🌘 убывающий серп",
+
+	"sunset.before":     "за %s до заката",
+	"sunset.after":      "через %s после заката",
+	"sunset.at":         "в момент заката",
+	"sunset.usage":      "Укажите смещение в минутах и текст. Пример:\n/remind_sunset -30 Закрыть теплицу",
+	"sunset.bad_offset": "Смещение — целое число минут от -720 до 720. Отрицательное — до заката.",
+	"sunset.failed":     "Не удалось получить время заката. Попробуйте позже.",
+	"sunset.saved":      "Буду напоминать каждый день (%s). Ближайшее — %s:\n«%s»",
+
+	"brief.greeting":           "🌞 Доброе утро! Сегодня %s, %s\n\n",
+	"brief.weather":            "🌡 Погода: %s, %s (ощущается как %s)\n",
+	"brief.reminders":          "⏰ Напоминания на сегодня:\n",
+	"brief.on":                 "🌅 Утренняя сводка включена: каждый день в %s.",
+	"brief.off":                "Утренняя сводка выключена.",
+	"brief.subscribe_failed":   "Не удалось оформить подписку. Попробуйте позже.",
+	"brief.subscribed":         "Вы подписаны на утреннюю сводку (%s %s). Изменить время: /settings",
+	"brief.unsubscribe_failed": "Не удалось отменить подписку. Попробуйте позже.",
+	"brief.unsubscribed":       "Вы отписаны от утренней сводки.",
+	"brief.air_usage":          "Используйте: /brief_air on или /brief_air off",
+	"brief.air_on":             "Качество воздуха будет добавляться в утреннюю сводку.",
+	"brief.air_off":            "Качество воздуха больше не будет добавляться в утреннюю сводку.",
+
+	"air.aqi1":        "хорошее",
+	"air.aqi2":        "удовлетворительное",
+	"air.aqi3":        "умеренное",
+	"air.aqi4":        "плохое",
+	"air.aqi5":        "очень плохое",
+	"air.report":      "🌫 Качество воздуха: %s (AQI %d из 5)\n• PM2.5: %s мкг/м³\n• PM10: %s мкг/м³\n• O₃: %s мкг/м³\n",
+	"air.alert_usage": "Укажите порог от 1 до 4 или off. Пример:\n/air_alert 3 — сообщить, когда AQI станет выше 3",
+	"air.alert_off":   "Оповещения о качестве воздуха выключены.",
+	"air.alert_on":    "Сообщу, когда индекс качества воздуха станет выше %d (%s).",
+	"air.alert":       "⚠️ Качество воздуха ухудшилось!\n\n",
+
+	"advice.title":           "\n💡 Советы:\n",
+	"advice.umbrella":        "☂️ Возьми зонт",
+	"advice.sunscreen":       "🧴 Нужен солнцезащитный крем",
+	"advice.ice":             "🧊 Возможен гололёд — осторожнее на дорогах",
+	"advice.cold":            "🧣 Одевайся теплее: шапка и перчатки",
+	"advice.heat":            "🥤 Жарко — возьми воду и головной убор",
+	"advice.wind":            "🌬️ Сильный ветер — зонт может не пережить прогулку",
+	"advice.reset":           "Пороги советов сброшены к значениям по умолчанию.",
+	"advice.not_number":      "Значение должно быть числом. Пример: /advice umbrella 50",
+	"advice.out_of_range":    "Значение %s должно быть от %s до %s.",
+	"advice.cold_above_heat": "Порог холода должен быть ниже порога жары: сейчас cold %s, heat %s.",
+	"advice.unknown_rule":    "Неизвестное правило. Доступны: umbrella, sunscreen, cold, heat, wind.",
+	"advice.usage":           "Используйте: /advice, /advice <правило> <значение> или /advice reset",
+	"advice.thresholds":      "Пороги советов:\n• umbrella — зонт при вероятности осадков от %.0f%%\n• sunscreen — крем при УФ-индексе от %.1f\n• cold — одеться теплее при ощущаемой температуре до %.1f°C\n• heat — жара при ощущаемой температуре от %.1f°C\n• wind — сильный ветер от %.1f м/с\n\nИзменить: /advice umbrella 50, сбросить: /advice reset",
+
+	"geo.usage":         "Напоминание по месту срабатывает, когда вы делитесь трансляцией геопозиции рядом с точкой.\nОтветьте командой на сообщение с геопозицией:\n/remind_at [радиус_м] текст\nили укажите координаты:\n/remind_at 55.139 27.684 [радиус_м] текст\nПример: /remind_at 300 Купить молоко",
+	"geo.bad_coords":    "координаты вне допустимого диапазона",
+	"geo.bad_radius":    "радиус должен быть от %d до %d метров",
+	"geo.no_text":       "не указан текст напоминания",
+	"geo.saved":         "📍 Напомню «%s», когда окажетесь в %d м от точки.\nНапоминание сработает по трансляции геопозиции (📎 → Геопозиция → Транслировать).",
+	"geo.delivered_for": "📍 Напоминание для @%s: %s",
+	"geo.delivered":     "📍 Напоминание: %s",
+	"geo.deleted":       "🗑 Напоминание по месту удалено",
+	"geo.not_found":     "Напоминание уже удалено или сработало",
+	"geo.not_yours":     "Удалить напоминание может только его автор",
+	"geo.delete_failed": "Не удалось удалить напоминание. Попробуйте позже.",
+
+	"location.save_failed": "Не удалось сохранить местоположение. Попробуйте позже.",
+	"location.saved":       "📍 Местоположение сохранено, погода и качество воздуха будут показываться для него.\nЧтобы получить напоминание, когда окажетесь здесь, ответьте на геопозицию: /remind_at текст",
+
+	"greet":          "С возвращением!\nМожет нужны настройки?",
+	"greet.settings": "⚙ Настройки",
+
+	"menu.main":           "Чем хотите воспользоваться?",
+	"menu.weather":        "Узнать погоду",
+	"menu.expenses":       "Посмотреть затраты",
+	"menu.currency":       "Узнать курс валюты",
+	"menu.settings":       "⚙️ Настройки",
+	"menu.weather_title":  "Выберите промежуток",
+	"menu.weather_today":  "Узнать погоду на день",
+	"menu.weather_now":    "Узнать текущую погоду",
+	"menu.air":            "Качество воздуха",
+	"menu.sun":            "Солнце и Луна",
+	"menu.currency_title": "Выберите курс:",
+	"menu.settings_title": "Настройки",
+	"menu.send_location":  "📍 Отправить местоположение",
+	"menu.brief":          "🌅 Утренняя сводка",
+	"menu.advice":         "💡 Советы по погоде",
+	"menu.calendar":       "📅 Календарь",
+	"menu.all_settings":   "🛠 Все настройки",
+	"menu.home":           "🏠 Главное меню",
+	"menu.back":           "⬅️ Назад: ",
+
+	"rates.failed":         "Не удалось получить курс. Попробуйте позже.",
+	"rates.line":           "Текущий курс %s",
+	"inline.rate_desc":     "Официальный курс Нацбанка Беларуси",
+	"inline.weather_title": "🌡 Погода: %s",
+	"inline.my_place":      "ваше место",
+
+	"settings.metric":          "Метрические (°C, м/с)",
+	"settings.imperial":        "Имперские (°F, миль/ч)",
+	"settings.lang":            "🌐 Язык",
+	"settings.lang_prompt":     "Выберите язык интерфейса.",
+	"settings.tz":              "🕒 Часовой пояс",
+	"settings.tz_prompt":       "Выберите часовой пояс.",
+	"settings.units":           "📏 Единицы",
+	"settings.units_prompt":    "Выберите единицы измерения.",
+	"settings.currency":        "💱 Базовая валюта",
+	"settings.currency_prompt": "Выберите валюту, в которой показывать курсы.",
+	"settings.brief":           "🌅 Утренняя сводка",
+	"settings.brief_prompt":    "Выберите время утренней сводки.",
+	"settings.brief_disable":   "Выключить",
+	"settings.brief_off":       "выключена",
+	"settings.brief_at":        "в %s",
+	"settings.loc":             "📍 Местоположение",
+	"settings.loc_prompt":      "Выберите местоположение для погоды.\nЧтобы задать своё место, отправьте геопозицию в чат.",
+	"settings.loc_reset":       "Сбросить на место по умолчанию",
+	"settings.loc_default":     "по умолчанию",
+	"settings.text":            "⚙️ Настройки чата\n\n🌐 Язык: %s\n🕒 Часовой пояс: %s\n📏 Единицы: %s\n💱 Базовая валюта: %s\n🌅 Утренняя сводка: %s\n📍 Местоположение: %s",
+	"settings.close":           "✖️ Закрыть",
+	"settings.closed":          "Настройки закрыты.",
+	"settings.back":            "⬅️ Назад",
+	"settings.bad_option":      "Такого варианта нет",
+	"settings.saved":           "Сохранено",
+
+	"picker.other_hour": "⬅️ Другой час",
+	"picker.no_dialog":  "Не удалось получить диалог, попробуйте позже",
+	"picker.stale":      "Этот выбор уже неактуален",
+	"picker.not_yours":  "Выбирать может только тот, кто начал диалог",
+
+	"flow.start_failed":      "Не удалось начать диалог. Попробуйте позже.",
+	"flow.save_failed":       "Не удалось сохранить ответ. Попробуйте позже.",
+	"flow.nothing_to_cancel": "Отменять нечего.",
+	"flow.cancelled":         "Отменено.",
+
+	"expense.ask_amount":   "💸 Сколько потратили? Сумма в BYN, например 12.50\nОтмена: /cancel",
+	"expense.bad_amount":   "Нужна положительная сумма, например 12.50",
+	"expense.ask_category": "🏷 Категория? Выберите или напишите свою.",
+	"expense.categories":   "Еда|Транспорт|Дом|Здоровье|Развлечения|Другое",
+	"expense.bad_category": "Категория — от 1 до %d символов.",
+	"expense.ask_date":     "📅 Когда потратили? Выберите день или напишите «сегодня» или «вчера».",
+	"expense.bad_date":     "Нужен сегодняшний или прошедший день, например: сегодня, вчера, 2025-06-20 или 20.06",
+	"expense.save_failed":  "Не удалось сохранить трату. Попробуйте позже.",
+	"expense.saved":        "Записал: %s — %s, %s\nЗа месяц: /expenses",
+	"expense.none":         "В этом месяце трат нет. Записать: /expense",
+	"expense.since":        "💰 Траты с %s:\n",
+	"expense.total":        "\nИтого: %s\nЗаписать трату: /expense",
+
+	"remind.ask_date":           "📅 На какой день? Выберите в календаре или напишите дату (завтра, 20.06).\nОтмена: /cancel",
+	"remind.bad_date":           "Не понял дату. Пример: завтра, 2025-06-20 или 20.06",
+	"remind.past_date":          "Этот день уже прошёл. Выберите другой.",
+	"remind.ask_time_text":      "🕒 Во сколько? Например, 9:00 или 18:30",
+	"remind.ask_time":           "🕒 Во сколько? Выберите час и минуты или напишите время (9:00, 18:30).",
+	"remind.bad_time":           "Не понял время. Пример: 9:00 или 18:30",
+	"remind.past_time":          "Это время уже прошло. Укажите время в будущем.",
+	"remind.ask_text":           "✏️ О чём напомнить?",
+	"remind.empty_text":         "Напишите текст напоминания.",
+	"remind.broken_dialog":      "Диалог повреждён, начните заново: /remind",
+	"remind.save_failed":        "Не удалось сохранить напоминание. Попробуйте позже.",
+	"remind.set":                "Напоминание установлено на %s",
+	"remind.quote":              ":\n«%s»",
+	"remind.usage":              "Формат: /remind [@пользователь] [лично] когда текст\nМожно ответить командой на сообщение: /remind через 2 часа — текст тогда не обязателен\nПримеры:\n/remind 2025-06-20 15:30 Купить цветы\n/remind завтра 10:00 Позвонить маме\n/remind через 2 часа Выключить духовку\n/remind @ivan завтра 10:00 ревью",
+	"remind.mention_private":    "Упоминать других можно только в группе. Чтобы напомнить себе, не указывайте @пользователя.",
+	"remind.foreign_limit":      "У вас уже %d активное напоминание для других. Дождитесь, пока оно сработает.|У вас уже %d активных напоминания для других. Дождитесь, пока они сработают.|У вас уже %d активных напоминаний для других. Дождитесь, пока они сработают.",
+	"remind.for":                " для %s",
+	"remind.for_private":        " для @%s (в личные сообщения)",
+	"remind.to_private":         " (в личные сообщения)",
+	"remind.private_only_group": "Отправить напоминание другому человеку лично можно только из общей группы.",
+	"remind.unseen_user":        "Не знаю @%s: пусть он сначала напишет боту — в этой группе или в личку.",
+	"remind.unknown_user":       "@%s ещё не запускал бота, поэтому напоминание можно отправить только в эту группу (без «лично»).",
+	"remind.not_member":         "@%s не состоит в этой группе.",
+	"remind.see_message":        "см. сообщение",
+	"remind.delivered_for":      "⌛ Напоминание для %s: %s",
+	"remind.delivered":          "⌛ Напоминание: %s",
+
+	"repeat.sunset":  " (каждый день, %s)",
+	"repeat.daily":   " (каждый день)",
+	"repeat.weekly":  " (каждую неделю)",
+	"repeat.monthly": " (каждый месяц)",
+	"repeat.yearly":  " (каждый год)",
+
+	"reminders.cleared":      "Удалено %d недоставленное напоминание|Удалено %d недоставленных напоминания|Удалено %d недоставленных напоминаний",
+	"reminders.none":         "Напоминаний нет. Создать: /remind YYYY-MM-DD HH:MM текст",
+	"reminders.reason":       "\n  причина: %s\n",
+	"reminders.retrying":     " (повторная отправка, %d попытка)| (повторная отправка, %d попытки)| (повторная отправка, %d попыток)",
+	"reminders.place":        "• %.5f, %.5f (%d м) — %s\n",
+	"reminders.place_delete": "🗑 %s",
+	"reminders.pending":      "⏰ Запланированные напоминания:\n",
+	"reminders.places":       "\n📍 Напоминания по месту:\n",
+	"reminders.groups":       "\n👥 Ваши напоминания в группах:\n",
+	"reminders.group_title":  " · %s",
+	"reminders.dead":         "\n❌ Не удалось доставить:\n",
+	"reminders.clear_hint":   "\nОчистить список: /reminders clear",
+
+	"ics.export_usage":      "Формат: /export reminders — выгрузить напоминания в файл .ics для календаря",
+	"ics.export_empty":      "Напоминаний нет — выгружать нечего.",
+	"ics.export_failed":     "Не удалось сформировать файл. Попробуйте позже.",
+	"ics.export_caption":    "📅 %d напоминание. Файл можно импортировать в любой календарь.|📅 %d напоминания. Файл можно импортировать в любой календарь.|📅 %d напоминаний. Файл можно импортировать в любой календарь.",
+	"ics.nothing_to_import": "В календаре нет будущих событий — импортировать нечего.",
+	"ics.already_imported":  "Все будущие события этого календаря уже импортированы.",
+	"ics.preview":           "📅 Будет создано %d напоминание\n|📅 Будет создано %d напоминания\n|📅 Будет создано %d напоминаний\n",
+	"ics.preview_more":      "… и ещё %d\n",
+	"ics.import_btn":        "✅ Импортировать",
+	"ics.cancel_btn":        "✖️ Отмена",
+	"ics.file_not_found":    "Файл для импорта не найден",
+	"ics.not_yours":         "Подтвердить импорт может только тот, кто прислал файл",
+	"ics.imported":          "✅ Импортировано %d напоминание|✅ Импортировано %d напоминания|✅ Импортировано %d напоминаний",
+	"ics.import_failed":     "\nНе удалось сохранить: %d",
+	"ics.list_hint":         "\nСписок: /reminders",
+	"ics.import_cancelled":  "Импорт отменён.",
+	"ics.too_big":           "Файл слишком большой: календарь должен быть не больше %d КБ.",
+	"ics.download_failed":   "Не удалось скачать файл. Попробуйте позже.",
+	"ics.parse_failed":      "Не удалось разобрать календарь: %v",
+	"ics.untitled":          "Событие из календаря",
+	"ics.note_past":         "пропущено прошедших событий: %d",
+	"ics.note_duplicates":   "уже импортированы раньше: %d",
+	"ics.note_unsupported":  "сложное правило повторения у %d события — оно сработает один раз|сложное правило повторения у %d событий — они сработают один раз|сложное правило повторения у %d событий — они сработают один раз",
+	"ics.note_too_many":     "не поместились в лимит %d: %d",
+
+	"caldav.usage":             "Синхронизация напоминаний с календарём CalDAV (Nextcloud, Radicale и др.):\n/caldav адрес_календаря логин пароль — подключить (только в личном чате)\n/caldav sync — синхронизировать сейчас\n/caldav off — отключить\nПример адреса: https://cloud.example.com/remote.php/dav/calendars/ivan/personal/\nИспользуйте пароль приложения, а не основной пароль.",
+	"caldav.result":            "из календаря: новых %d, изменено %d, удалено %d; в календарь: новых %d, изменено %d, удалено %d",
+	"caldav.result_skipped":    "\n⚠️ Не удалось прочитать объектов календаря: %d",
+	"caldav.kept":              "изменения из календаря не применены, напоминания оставлены как были: %s",
+	"caldav.kept_rrule":        "«%s» — правило повторения %s не поддерживается",
+	"caldav.kept_past":         "«%s» — событие перенесено в прошлое",
+	"caldav.sync_failed":       "Не удалось синхронизировать календарь: %v",
+	"caldav.synced":            "🔄 Синхронизировано — %s",
+	"caldav.disconnected":      "Календарь отключён. Напоминания остались в боте и в календаре.",
+	"caldav.private_only":      "Подключать календарь можно только в личном чате с ботом — там не видно пароля.",
+	"caldav.open_failed":       "Не удалось открыть календарь: %v\nПроверьте адрес, логин и пароль.",
+	"caldav.first_sync_failed": "📅 Календарь подключён, но первая синхронизация не удалась: %v",
+	"caldav.connected":         "📅 Календарь подключён, синхронизация %s.\n%s",
+	"caldav.status":            "📅 Календарь: %s (%s)\n",
+	"caldav.never_synced":      "Ещё не синхронизировался\n",
+	"caldav.last_sync":         "Последняя синхронизация: %s\n",
+	"caldav.last_error":        "⚠️ Ошибка: %s\n",
+	"caldav.status_hint":       "\n/caldav sync — синхронизировать сейчас, /caldav off — отключить",
+
+	"plural.minutes_every": "каждую %d минуту|каждые %d минуты|каждые %d минут",
+	"plural.minutes":       "%d минуту|%d минуты|%d минут",
+	"plural.days":          "%d день|%d дня|%d дней",
+
+	"settings.save_failed": "Не удалось сохранить настройки. Попробуйте позже.",
+	"settings.load_failed": "Не удалось получить настройки. Попробуйте позже.",
+}
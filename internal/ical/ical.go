@@ -0,0 +1,290 @@
+// Package ical читает и записывает события в формате iCalendar (RFC 5545)
+// в объёме, нужном для импорта и экспорта напоминаний: VEVENT с DTSTART,
+// SUMMARY, RRULE и нестандартными свойствами X-*. Задачи VTODO читаются
+// как события со временем из DUE.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Event — событие календаря
+type Event struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	AllDay  bool              // DTSTART без времени (VALUE=DATE)
+	RRule   string            // правило повторения как есть, например "FREQ=WEEKLY"; пусто — однократное
+	Status  string            // STATUS: CANCELLED у отменённых событий, COMPLETED у выполненных задач
+	Todo    bool              // прочитано из VTODO (Encode всегда пишет VEVENT)
+	Extra   map[string]string // нестандартные свойства X-*
+}
+
+// Active сообщает, что событие не отменено, а задача не выполнена
+func (e Event) Active() bool {
+	return e.Status != "CANCELLED" && e.Status != "COMPLETED"
+}
+
+const (
+	utcLayout      = "20060102T150405Z"
+	floatingLayout = "20060102T150405"
+	dateLayout     = "20060102"
+
+	maxLineLen = 75 // длина строки в октетах без CRLF (RFC 5545, 3.1)
+)
+
+// ProdID — PRODID календарей, которые выгружает бот: файлов экспорта и объектов CalDAV
+const ProdID = "-//tg-bot//reminders//RU"
+
+// Encode записывает события одним календарём VCALENDAR
+func Encode(w io.Writer, prodID string, events []Event) error {
+	bw := bufio.NewWriter(w)
+	stamp := time.Now().UTC().Format(utcLayout)
+
+	line := func(name, value string) {
+		writeFolded(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", prodID)
+	line("CALSCALE", "GREGORIAN")
+	for _, e := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", e.UID)
+		line("DTSTAMP", stamp)
+		if e.AllDay {
+			line("DTSTART;VALUE=DATE", e.Start.Format(dateLayout))
+		} else {
+			line("DTSTART", e.Start.UTC().Format(utcLayout))
+		}
+		line("SUMMARY", escapeText(e.Summary))
+		if e.RRule != "" {
+			line("RRULE", e.RRule)
+		}
+		if e.Status != "" {
+			line("STATUS", e.Status)
+		}
+		names := make([]string, 0, len(e.Extra))
+		for name := range e.Extra {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			line(name, escapeText(e.Extra[name]))
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+
+	return bw.Flush()
+}
+
+// writeFolded пишет строку, перенося её на продолжения по 75 октетов,
+// не разрывая многобайтовые символы UTF-8
+func writeFolded(w *bufio.Writer, s string) {
+	limit := maxLineLen
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(s[cut]) {
+			cut--
+		}
+		w.WriteString(s[:cut] + "\r\n ")
+		s = s[cut:]
+		limit = maxLineLen - 1 // строка продолжения начинается с пробела
+	}
+	w.WriteString(s + "\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// unescapeText обращает escapeText; неизвестные последовательности оставляет без «\»
+func unescapeText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// property — одна строка контента: NAME;PARAM=VALUE:значение
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse читает все VEVENT из календаря. Время без зоны и без TZID
+// (а также с неизвестной TZID) считается временем в loc.
+func Parse(r io.Reader, loc *time.Location) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events []Event
+		cur    *Event
+		depth  int  // вложенные компоненты внутри VEVENT (VALARM и т.п.)
+		hasDue bool // у текущей задачи уже прочитан DUE
+	)
+	for n, raw := range lines {
+		p, err := parseProperty(raw)
+		if err != nil {
+			return nil, fmt.Errorf("строка %d: %w", n+1, err)
+		}
+
+		switch {
+		case p.name == "BEGIN" && cur == nil && (strings.EqualFold(p.value, "VEVENT") || strings.EqualFold(p.value, "VTODO")):
+			cur = &Event{Todo: strings.EqualFold(p.value, "VTODO")}
+		case cur == nil:
+			// Свойства календаря и других компонентов не нужны
+		case p.name == "BEGIN":
+			depth++
+		case p.name == "END" && depth > 0:
+			depth--
+		case depth > 0:
+		case p.name == "END":
+			switch {
+			case cur.Start.IsZero() && cur.Todo:
+				// Задача без срока — напоминать не о чем
+			case cur.Start.IsZero():
+				return nil, fmt.Errorf("строка %d: у события нет DTSTART", n+1)
+			default:
+				events = append(events, *cur)
+			}
+			cur, hasDue = nil, false
+		case p.name == "UID":
+			cur.UID = p.value
+		case p.name == "SUMMARY":
+			cur.Summary = unescapeText(p.value)
+		case p.name == "RRULE":
+			cur.RRule = p.value
+		case p.name == "STATUS":
+			cur.Status = strings.ToUpper(p.value)
+		case p.name == "DTSTART" && !(cur.Todo && hasDue),
+			p.name == "DUE" && cur.Todo:
+			// У задачи напоминать нужно о сроке, а не о начале
+			cur.Start, cur.AllDay, err = parseStart(p, loc)
+			if err != nil {
+				return nil, fmt.Errorf("строка %d: %w", n+1, err)
+			}
+			hasDue = hasDue || p.name == "DUE"
+		case strings.HasPrefix(p.name, "X-"):
+			if cur.Extra == nil {
+				cur.Extra = make(map[string]string)
+			}
+			cur.Extra[p.name] = unescapeText(p.value)
+		}
+	}
+
+	if cur != nil {
+		return nil, fmt.Errorf("событие не закрыто (нет END:VEVENT)")
+	}
+	return events, nil
+}
+
+// unfold читает строки контента, склеивая продолжения (строки с пробелом или табом в начале)
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for sc.Scan() {
+		l := strings.TrimRight(sc.Text(), "\r")
+		if l == "" {
+			continue
+		}
+		if (l[0] == ' ' || l[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		lines = append(lines, l)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения календаря: %w", err)
+	}
+	return lines, nil
+}
+
+// parseProperty разбирает строку контента с учётом кавычек в параметрах
+func parseProperty(line string) (property, error) {
+	colon, quoted := -1, false
+	for i := 0; i < len(line) && colon < 0; i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				colon = i
+			}
+		}
+	}
+	if colon < 0 {
+		return property{}, fmt.Errorf("нет «:» в строке %q", line)
+	}
+
+	head := strings.Split(line[:colon], ";")
+	p := property{
+		name:   strings.ToUpper(head[0]),
+		params: make(map[string]string),
+		value:  line[colon+1:],
+	}
+	for _, param := range head[1:] {
+		k, v, _ := strings.Cut(param, "=")
+		p.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return p, nil
+}
+
+// parseStart разбирает DTSTART: дату, время UTC, время с TZID или «плавающее» время
+func parseStart(p property, loc *time.Location) (time.Time, bool, error) {
+	if strings.EqualFold(p.params["VALUE"], "DATE") || len(p.value) == len(dateLayout) {
+		t, err := time.ParseInLocation(dateLayout, p.value, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("неверная дата %q", p.value)
+		}
+		return t, true, nil
+	}
+
+	if strings.HasSuffix(p.value, "Z") {
+		t, err := time.Parse(utcLayout, p.value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("неверное время %q", p.value)
+		}
+		return t, false, nil
+	}
+
+	if tzid := p.params["TZID"]; tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			loc = tz
+		}
+	}
+	t, err := time.ParseInLocation(floatingLayout, p.value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("неверное время %q", p.value)
+	}
+	return t, false, nil
+}
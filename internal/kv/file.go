@@ -0,0 +1,212 @@
+package kv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// fileStore хранит все ключи в одном JSON-файле. Подходит для одного
+// долгоживущего процесса (например, на Fly.io с подключённым томом),
+// поэтому блокировки действуют только внутри процесса.
+type fileStore struct {
+	mu    sync.Mutex
+	path  string
+	data  map[string][]byte
+	locks map[string]time.Time // ключ блокировки → когда она истекает
+}
+
+// NewFileStore открывает (или создаёт) хранилище в файле path
+func NewFileStore(path string) (Store, error) {
+	s := &fileStore{
+		path:  path,
+		data:  make(map[string][]byte),
+		locks: make(map[string]time.Time),
+	}
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать %s: %w", path, err)
+	}
+	if err := json.Unmarshal(raw, &s.data); err != nil {
+		return nil, fmt.Errorf("не удалось разобрать %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+func (s *fileStore) Get(key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.data[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return value, nil
+}
+
+func (s *fileStore) Set(key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data[key] = value
+	return s.flush()
+}
+
+func (s *fileStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.data, key)
+	return s.flush()
+}
+
+func (s *fileStore) TryLock(key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if expires, ok := s.locks[key]; ok && now.Before(expires) {
+		return false, nil
+	}
+	s.locks[key] = now.Add(ttl)
+	return true, nil
+}
+
+func (s *fileStore) Unlock(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.locks, key)
+	return nil
+}
+
+func (s *fileStore) MGet(keys []string) ([][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i] = s.data[key]
+	}
+	return values, nil
+}
+
+// Упорядоченное множество хранится как обычный ключ: JSON-объект «участник → вес»
+
+func (s *fileStore) ZAdd(key string, member string, score int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	set, err := s.zset(key)
+	if err != nil {
+		return err
+	}
+	set[member] = score
+	return s.saveZSet(key, set)
+}
+
+func (s *fileStore) ZRem(key string, member string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	set, err := s.zset(key)
+	if err != nil {
+		return err
+	}
+	if _, ok := set[member]; !ok {
+		return nil
+	}
+	delete(set, member)
+	return s.saveZSet(key, set)
+}
+
+func (s *fileStore) ZRange(key string, max int64, limit int) ([]Scored, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	set, err := s.zset(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []Scored
+	for member, score := range set {
+		if score <= max {
+			res = append(res, Scored{Member: member, Score: score})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Score != res[j].Score {
+			return res[i].Score < res[j].Score
+		}
+		return res[i].Member < res[j].Member
+	})
+	if limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+func (s *fileStore) zset(key string) (map[string]int64, error) {
+	set := make(map[string]int64)
+	raw, ok := s.data[key]
+	if !ok {
+		return set, nil
+	}
+	if err := json.Unmarshal(raw, &set); err != nil {
+		return nil, fmt.Errorf("ключ %s не упорядоченное множество: %w", key, err)
+	}
+	return set, nil
+}
+
+func (s *fileStore) saveZSet(key string, set map[string]int64) error {
+	if len(set) == 0 {
+		delete(s.data, key)
+		return s.flush()
+	}
+	raw, err := json.Marshal(set)
+	if err != nil {
+		return err
+	}
+	s.data[key] = raw
+	return s.flush()
+}
+
+// flush атомарно перезаписывает файл: пишем во временный и переименовываем,
+// чтобы при падении посреди записи не остаться с повреждённым файлом
+func (s *fileStore) flush() error {
+	raw, err := json.Marshal(s.data)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("не удалось создать временный файл: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return fmt.Errorf("не удалось записать %s: %w", tmp.Name(), err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,60 @@
+package kv
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrNotFound возвращается, если ключа нет в хранилище
+var ErrNotFound = errors.New("ключ не найден")
+
+// Store — простое хранилище «ключ → значение» с блокировками.
+// Блокировки нужны, чтобы несколько экземпляров бота (например, serverless-функции)
+// не перезаписывали изменения друг друга.
+type Store interface {
+	Get( key string ) ( []byte, error )                     // значение по ключу или ErrNotFound
+	Set( key string, value []byte ) error                   // записать значение
+	Delete( key string ) error                              // удалить ключ
+	TryLock( key string, ttl time.Duration ) ( bool, error ) // захватить блокировку; false — она занята
+	Unlock( key string ) error                              // снять блокировку, захваченную этим экземпляром
+	MGet( keys []string ) ( [][]byte, error )               // значения нескольких ключей; nil — ключа нет
+
+	// Упорядоченные множества (ZSET в Redis): участник → вес, выборка по весу
+	ZAdd( key string, member string, score int64 ) error            // добавить участника или изменить его вес
+	ZRem( key string, member string ) error                         // убрать участника
+	ZRange( key string, max int64, limit int ) ( []Scored, error ) // участники с весом ≤ max по возрастанию; limit ≤ 0 — все
+}
+
+// Scored — участник упорядоченного множества и его вес
+type Scored struct {
+	Member string
+	Score  int64
+}
+
+// MaxScore — верхняя граница веса для ZRange, когда нужны все участники
+const MaxScore = math.MaxInt64
+
+// lockRetry — пауза между попытками захватить занятую блокировку
+const lockRetry = 50 * time.Millisecond
+
+// WithLock выполняет fn под блокировкой key, ожидая её освобождения не дольше wait
+func WithLock(s Store, key string, ttl time.Duration, wait time.Duration, fn func() error) error {
+	deadline := time.Now().Add(wait)
+	for {
+		ok, err := s.TryLock(key, ttl)
+		if err != nil {
+			return err
+		}
+		if ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			return errors.New("не удалось захватить блокировку " + key)
+		}
+		time.Sleep(lockRetry)
+	}
+	defer s.Unlock(key)
+
+	return fn()
+}
@@ -0,0 +1,175 @@
+package kv
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	resty "resty.dev/v3"
+)
+
+// unlockScript снимает блокировку, только если она всё ещё наша
+const unlockScript = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("del", KEYS[1]) else return 0 end`
+
+// upstashStore работает с Redis через REST API Upstash (он же Vercel KV).
+// Подходит для serverless: состояние общее для всех экземпляров функции.
+type upstashStore struct {
+	client *resty.Client
+	url    string
+
+	mu     sync.Mutex
+	tokens map[string]string // ключ блокировки → наш токен
+}
+
+type upstashRes struct {
+	Result any    `json:"result"`
+	Error  string `json:"error"`
+}
+
+// NewUpstashStore создаёт хранилище поверх Upstash Redis REST API
+func NewUpstashStore(url string, token string) Store {
+	return &upstashStore{
+		client: resty.New().SetTimeout( 5 * time.Second ).SetRetryCount( 1 ).SetAuthToken( token ),
+		url:    url,
+		tokens: make(map[string]string),
+	}
+}
+
+// command выполняет одну команду Redis и возвращает её результат
+func (s *upstashStore) command(args ...string) (any, error) {
+	var data upstashRes
+
+	res, err := s.client.R().SetBody( args ).SetResult( &data ).SetError( &data ).Post( s.url )
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	if res.IsError() || data.Error != "" {
+		return nil, fmt.Errorf("Upstash вернул ошибку %s: %s", res.Status(), data.Error)
+	}
+
+	return data.Result, nil
+}
+
+func (s *upstashStore) Get(key string) ([]byte, error) {
+	result, err := s.command( "GET", key )
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := result.(string)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return []byte(value), nil
+}
+
+func (s *upstashStore) Set(key string, value []byte) error {
+	_, err := s.command( "SET", key, string(value) )
+	return err
+}
+
+func (s *upstashStore) Delete(key string) error {
+	_, err := s.command( "DEL", key )
+	return err
+}
+
+func (s *upstashStore) TryLock(key string, ttl time.Duration) (bool, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return false, err
+	}
+	token := hex.EncodeToString(buf)
+
+	result, err := s.command( "SET", key, token, "NX", "PX", strconv.FormatInt( ttl.Milliseconds(), 10 ) )
+	if err != nil {
+		return false, err
+	}
+	if result != "OK" {
+		return false, nil
+	}
+
+	s.mu.Lock()
+	s.tokens[key] = token
+	s.mu.Unlock()
+	return true, nil
+}
+
+func (s *upstashStore) Unlock(key string) error {
+	s.mu.Lock()
+	token, ok := s.tokens[key]
+	delete(s.tokens, key)
+	s.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	_, err := s.command( "EVAL", unlockScript, "1", key, token )
+	return err
+}
+
+// mgetBatch — сколько ключей запрашивать одной командой MGET
+const mgetBatch = 500
+
+func (s *upstashStore) MGet(keys []string) ([][]byte, error) {
+	values := make([][]byte, 0, len(keys))
+	for start := 0; start < len(keys); start += mgetBatch {
+		end := min(start+mgetBatch, len(keys))
+
+		result, err := s.command( append([]string{"MGET"}, keys[start:end]...)... )
+		if err != nil {
+			return nil, err
+		}
+		items, _ := result.([]any)
+		if len(items) != end-start {
+			return nil, fmt.Errorf("MGET вернул %d значений вместо %d", len(items), end-start)
+		}
+		for _, item := range items {
+			if value, ok := item.(string); ok {
+				values = append(values, []byte(value))
+			} else {
+				values = append(values, nil)
+			}
+		}
+	}
+	return values, nil
+}
+
+func (s *upstashStore) ZAdd(key string, member string, score int64) error {
+	_, err := s.command( "ZADD", key, strconv.FormatInt( score, 10 ), member )
+	return err
+}
+
+func (s *upstashStore) ZRem(key string, member string) error {
+	_, err := s.command( "ZREM", key, member )
+	return err
+}
+
+func (s *upstashStore) ZRange(key string, max int64, limit int) ([]Scored, error) {
+	args := []string{ "ZRANGEBYSCORE", key, "-inf", strconv.FormatInt( max, 10 ), "WITHSCORES" }
+	if limit > 0 {
+		args = append(args, "LIMIT", "0", strconv.Itoa( limit ))
+	}
+
+	result, err := s.command( args... )
+	if err != nil {
+		return nil, err
+	}
+
+	// Ответ — плоский список: участник, вес, участник, вес…
+	items, _ := result.([]any)
+	res := make([]Scored, 0, len(items)/2)
+	for i := 0; i+1 < len(items); i += 2 {
+		member, _ := items[i].(string)
+		raw, _ := items[i+1].(string)
+		score, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("неверный вес %q в %s: %w", raw, key, err)
+		}
+		res = append(res, Scored{Member: member, Score: int64(score)})
+	}
+	return res, nil
+}
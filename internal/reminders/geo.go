@@ -0,0 +1,197 @@
+package reminders
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+
+	"tg-bot/internal/kv"
+)
+
+// GeoReminder — напоминание, привязанное к месту, а не ко времени:
+// срабатывает, когда автор оказывается в радиусе Radius от точки
+type GeoReminder struct {
+	ID       string
+	ChatID   int64
+	AuthorID int64
+	Text     string
+	Lat      float64
+	Lon      float64
+	Radius   float64 // метры
+}
+
+type GeoStorage interface {
+	Add( rem GeoReminder ) error                 // добавить напоминание (ID назначается автоматически)
+	ListByChat( chatID int64 ) []GeoReminder     // напоминания чата
+	Delete( chatID int64, id string ) error      // удалить (после срабатывания или вручную)
+	Close() error                                // сбросить несохранённые данные перед остановкой
+}
+
+// earthRadius — средний радиус Земли в метрах
+const earthRadius = 6371000
+
+// Distance возвращает расстояние между двумя точками в метрах (формула гаверсинусов)
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// Near сообщает, находится ли точка в радиусе напоминания
+func (r GeoReminder) Near(lat, lon float64) bool {
+	return Distance(r.Lat, r.Lon, lat, lon) <= r.Radius
+}
+
+type memoryGeoStorage struct {
+	mu     sync.Mutex
+	byChat map[int64][]GeoReminder
+}
+
+// NewMemoryGeoStorage создаёт in-memory хранилище напоминаний по месту
+func NewMemoryGeoStorage() GeoStorage {
+	return &memoryGeoStorage{byChat: make(map[int64][]GeoReminder)}
+}
+
+func (m *memoryGeoStorage) Add(rem GeoReminder) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if rem.ID == "" {
+		rem.ID = newID()
+	}
+	m.byChat[rem.ChatID] = append(m.byChat[rem.ChatID], rem)
+	return nil
+}
+
+func (m *memoryGeoStorage) ListByChat(chatID int64) []GeoReminder {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]GeoReminder(nil), m.byChat[chatID]...)
+}
+
+func (m *memoryGeoStorage) Delete(chatID int64, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	list, err := removeGeo(m.byChat[chatID], id)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		delete(m.byChat, chatID)
+	} else {
+		m.byChat[chatID] = list
+	}
+	return nil
+}
+
+// Close ничего не делает: in-memory хранилищу нечего сбрасывать
+func (m *memoryGeoStorage) Close() error {
+	return nil
+}
+
+const kvGeoPrefix = "geo:" // + ID чата: напоминания по месту этого чата
+
+// kvGeoStorage хранит напоминания по месту в kv.Store: у каждого чата свой ключ
+// со списком и своя блокировка, поэтому срабатывание в одном чате не ждёт
+// изменений в других и не перечитывает чужие напоминания
+type kvGeoStorage struct {
+	store kv.Store
+}
+
+// NewKVGeoStorage создаёт хранилище напоминаний по месту поверх kv.Store
+func NewKVGeoStorage(store kv.Store) GeoStorage {
+	return &kvGeoStorage{store: store}
+}
+
+func geoKey(chatID int64) string {
+	return kvGeoPrefix + strconv.FormatInt(chatID, 10)
+}
+
+func (s *kvGeoStorage) load(chatID int64) ([]GeoReminder, error) {
+	raw, err := s.store.Get(geoKey(chatID))
+	if errors.Is(err, kv.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var list []GeoReminder
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// update изменяет список чата под его блокировкой; пустой список удаляет ключ
+func (s *kvGeoStorage) update(chatID int64, change func(list []GeoReminder) ([]GeoReminder, error)) error {
+	return kv.WithLock(s.store, geoKey(chatID)+":lock", kvLockTTL, kvLockWait, func() error {
+		list, err := s.load(chatID)
+		if err != nil {
+			return err
+		}
+
+		list, err = change(list)
+		if err != nil {
+			return err
+		}
+
+		if len(list) == 0 {
+			if err := s.store.Delete(geoKey(chatID)); err != nil && !errors.Is(err, kv.ErrNotFound) {
+				return err
+			}
+			return nil
+		}
+		raw, err := json.Marshal(list)
+		if err != nil {
+			return err
+		}
+		return s.store.Set(geoKey(chatID), raw)
+	})
+}
+
+func (s *kvGeoStorage) Add(rem GeoReminder) error {
+	if rem.ID == "" {
+		rem.ID = newID()
+	}
+	return s.update(rem.ChatID, func(list []GeoReminder) ([]GeoReminder, error) {
+		return append(list, rem), nil
+	})
+}
+
+func (s *kvGeoStorage) ListByChat(chatID int64) []GeoReminder {
+	list, err := s.load(chatID)
+	if err != nil {
+		logStorageError("ListByChat", err)
+		return nil
+	}
+	return list
+}
+
+func (s *kvGeoStorage) Delete(chatID int64, id string) error {
+	return s.update(chatID, func(list []GeoReminder) ([]GeoReminder, error) {
+		return removeGeo(list, id)
+	})
+}
+
+// Close ничего не делает: каждое изменение записывается сразу
+func (s *kvGeoStorage) Close() error {
+	return nil
+}
+
+func removeGeo(list []GeoReminder, id string) ([]GeoReminder, error) {
+	for i := range list {
+		if list[i].ID == id {
+			return append(list[:i], list[i+1:]...), nil
+		}
+	}
+	return list, ErrNotFound
+}
@@ -0,0 +1,60 @@
+package reminders
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"tg-bot/internal/kv"
+)
+
+func TestGeoStorageByChat(t *testing.T) {
+	store, err := kv.NewFileStore(filepath.Join(t.TempDir(), "kv.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	geo := map[string]GeoStorage{"memory": NewMemoryGeoStorage(), "kv": NewKVGeoStorage(store)}
+
+	for name, s := range geo {
+		t.Run(name, func(t *testing.T) {
+			for _, r := range []GeoReminder{
+				{ID: "a", ChatID: 1, Text: "молоко"},
+				{ID: "b", ChatID: 1, Text: "хлеб"},
+				{ID: "c", ChatID: 2, Text: "почта"},
+			} {
+				if err := s.Add(r); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if list := s.ListByChat(1); len(list) != 2 || list[0].ID != "a" || list[1].ID != "b" {
+				t.Fatalf("ListByChat(1) = %+v", list)
+			}
+			if err := s.Delete(2, "a"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("удаление чужого напоминания: %v, ожидалось ErrNotFound", err)
+			}
+			if err := s.Delete(1, "a"); err != nil {
+				t.Fatal(err)
+			}
+			if list := s.ListByChat(1); len(list) != 1 || list[0].ID != "b" {
+				t.Errorf("после удаления ListByChat(1) = %+v", list)
+			}
+			if err := s.Delete(1, "b"); err != nil {
+				t.Fatal(err)
+			}
+			if list := s.ListByChat(1); len(list) != 0 {
+				t.Errorf("после удаления всех ListByChat(1) = %+v", list)
+			}
+			if list := s.ListByChat(2); len(list) != 1 || list[0].ID != "c" {
+				t.Errorf("ListByChat(2) = %+v", list)
+			}
+		})
+	}
+
+	if _, err := store.Get("geo:1"); !errors.Is(err, kv.ErrNotFound) {
+		t.Errorf("ключ пустого чата не удалён: %v", err)
+	}
+	if _, err := store.Get("geo:2"); err != nil {
+		t.Errorf("ключ чата 2: %v", err)
+	}
+}
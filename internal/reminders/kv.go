@@ -0,0 +1,237 @@
+package reminders
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"tg-bot/internal/kv"
+)
+
+const (
+	kvPrefix   = "reminder:"       // + ID: само напоминание
+	kvDueKey   = "reminders:due"   // ожидающие напоминания, вес — момент срабатывания (мс Unix)
+	kvAllKey   = "reminders:all"   // ID всех напоминаний, включая недоставленные
+	kvLockTTL  = 10 * time.Second  // блокировка снимется сама, если экземпляр упал
+	kvLockWait = 5 * time.Second
+	claimBatch = 100 // сколько сработавших напоминаний захватывать за один вызов Claim
+)
+
+// kvStorage хранит каждое напоминание под своим ключом, а порядок срабатывания —
+// в упорядоченном множестве kvDueKey. Claim и NextDue читают только вершину
+// множества, поэтому не зависят от общего числа напоминаний. Изменения одного
+// напоминания выполняются под его блокировкой: несколько экземпляров бота
+// (serverless-функции) не теряют изменения друг друга, а Claim отдаёт
+// напоминание только одному из них.
+type kvStorage struct {
+	store kv.Store
+}
+
+// NewKVStorage создаёт хранилище напоминаний поверх постоянного kv.Store
+func NewKVStorage(store kv.Store) Storage {
+	return &kvStorage{store: store}
+}
+
+func reminderKey(id string) string {
+	return kvPrefix + id
+}
+
+func lockKey(id string) string {
+	return kvPrefix + id + ":lock"
+}
+
+func score(t time.Time) int64 {
+	return t.UnixMilli()
+}
+
+func (s *kvStorage) load(id string) (Reminder, error) {
+	raw, err := s.store.Get(reminderKey(id))
+	if errors.Is(err, kv.ErrNotFound) {
+		return Reminder{}, ErrNotFound
+	}
+	if err != nil {
+		return Reminder{}, err
+	}
+
+	var r Reminder
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return Reminder{}, err
+	}
+	return r, nil
+}
+
+// save записывает напоминание и обновляет индексы: недоставленные
+// из очереди убираются, остальные ставятся по времени срабатывания
+func (s *kvStorage) save(r Reminder) error {
+	raw, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := s.store.Set(reminderKey(r.ID), raw); err != nil {
+		return err
+	}
+	if err := s.store.ZAdd(kvAllKey, r.ID, 0); err != nil {
+		return err
+	}
+	if r.State == StateDead {
+		return s.store.ZRem(kvDueKey, r.ID)
+	}
+	return s.store.ZAdd(kvDueKey, r.ID, score(dueAt(r)))
+}
+
+// modify применяет изменение к напоминанию с заданным ID под его блокировкой
+func (s *kvStorage) modify(id string, change func(r *Reminder)) error {
+	return kv.WithLock(s.store, lockKey(id), kvLockTTL, kvLockWait, func() error {
+		r, err := s.load(id)
+		if err != nil {
+			return err
+		}
+		change(&r)
+		return s.save(r)
+	})
+}
+
+func (s *kvStorage) Add(rem Reminder) error {
+	rem = prepareNew(rem)
+	return kv.WithLock(s.store, lockKey(rem.ID), kvLockTTL, kvLockWait, func() error {
+		return s.save(rem)
+	})
+}
+
+// Claim захватывает сработавшие напоминания с вершины очереди. Напоминание,
+// которое сейчас меняет другой экземпляр, пропускается до следующего вызова.
+func (s *kvStorage) Claim(now time.Time, lease time.Duration) []Reminder {
+	top, err := s.store.ZRange(kvDueKey, score(now), claimBatch)
+	if err != nil {
+		logStorageError("Claim", err)
+		return nil
+	}
+
+	var due []Reminder
+	for _, item := range top {
+		r, ok, err := s.claim(item.Member, now, lease)
+		if err != nil {
+			// Захват не сохранился — не отдаём напоминание, чтобы не отправить его дважды
+			logStorageError("Claim", err)
+			continue
+		}
+		if ok {
+			due = append(due, r)
+		}
+	}
+	return due
+}
+
+// claim захватывает одно напоминание, если оно всё ещё ждёт отправки и сработало
+func (s *kvStorage) claim(id string, now time.Time, lease time.Duration) (Reminder, bool, error) {
+	locked, err := s.store.TryLock(lockKey(id), kvLockTTL)
+	if err != nil || !locked {
+		return Reminder{}, false, err
+	}
+	defer s.store.Unlock(lockKey(id))
+
+	r, err := s.load(id)
+	if errors.Is(err, ErrNotFound) {
+		// Индекс пережил само напоминание (экземпляр упал посреди удаления)
+		return Reminder{}, false, s.store.ZRem(kvDueKey, id)
+	}
+	if err != nil {
+		return Reminder{}, false, err
+	}
+	if r.State != StatePending || now.Before(dueAt(r)) {
+		// Индекс устарел — поправляем его и ничего не захватываем
+		return Reminder{}, false, s.save(r)
+	}
+
+	r.Attempts++
+	r.LeaseUntil = now.Add(lease)
+	if err := s.save(r); err != nil {
+		return Reminder{}, false, err
+	}
+	return r, true, nil
+}
+
+func (s *kvStorage) NextDue() (time.Time, bool) {
+	top, err := s.store.ZRange(kvDueKey, kv.MaxScore, 1)
+	if err != nil {
+		logStorageError("NextDue", err)
+		return time.Time{}, false
+	}
+	if len(top) == 0 {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(top[0].Score), true
+}
+
+func (s *kvStorage) Ack(id string) error {
+	return s.Delete(id)
+}
+
+func (s *kvStorage) Retry(id string, at time.Time, reason string) error {
+	return s.modify(id, func(r *Reminder) { markRetry(r, at, reason) })
+}
+
+func (s *kvStorage) Reschedule(id string, at time.Time) error {
+	return s.modify(id, func(r *Reminder) { markRescheduled(r, at) })
+}
+
+func (s *kvStorage) Fail(id string, reason string) error {
+	return s.modify(id, func(r *Reminder) { markDead(r, reason) })
+}
+
+func (s *kvStorage) Update(id string, change func(r *Reminder)) error {
+	return s.modify(id, change)
+}
+
+func (s *kvStorage) Delete(id string) error {
+	return kv.WithLock(s.store, lockKey(id), kvLockTTL, kvLockWait, func() error {
+		if _, err := s.load(id); err != nil {
+			return err
+		}
+		// Сначала индексы: если упадём посередине, останется лишь ключ без ссылок на него
+		if err := s.store.ZRem(kvDueKey, id); err != nil {
+			return err
+		}
+		if err := s.store.ZRem(kvAllKey, id); err != nil {
+			return err
+		}
+		return s.store.Delete(reminderKey(id))
+	})
+}
+
+func (s *kvStorage) ListAll() []Reminder {
+	ids, err := s.store.ZRange(kvAllKey, kv.MaxScore, 0)
+	if err != nil {
+		logStorageError("ListAll", err)
+		return nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, item := range ids {
+		keys[i] = reminderKey(item.Member)
+	}
+	values, err := s.store.MGet(keys)
+	if err != nil {
+		logStorageError("ListAll", err)
+		return nil
+	}
+
+	list := make([]Reminder, 0, len(values))
+	for _, raw := range values {
+		if raw == nil {
+			continue
+		}
+		var r Reminder
+		if err := json.Unmarshal(raw, &r); err != nil {
+			logStorageError("ListAll", err)
+			continue
+		}
+		list = append(list, r)
+	}
+	return list
+}
+
+// Close ничего не делает: каждое изменение записывается сразу
+func (s *kvStorage) Close() error {
+	return nil
+}
@@ -0,0 +1,94 @@
+package reminders
+
+import (
+	"context"
+	"time"
+)
+
+// maxIdle — как долго планировщик спит без напоминаний. Нужен, чтобы заметить
+// изменения, сделанные другими экземплярами бота в общем хранилище.
+const maxIdle = time.Minute
+
+// Scheduler оборачивает Storage и будит цикл отправки при каждом изменении,
+// чтобы он засыпал ровно до ближайшего напоминания, а не опрашивал хранилище
+type Scheduler struct {
+	Storage
+	wake chan struct{}
+}
+
+// NewScheduler создаёт планировщик поверх хранилища
+func NewScheduler(storage Storage) *Scheduler {
+	return &Scheduler{
+		Storage: storage,
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+func (s *Scheduler) Add(rem Reminder) error {
+	defer s.notify()
+	return s.Storage.Add(rem)
+}
+
+func (s *Scheduler) Retry(id string, at time.Time, reason string) error {
+	defer s.notify()
+	return s.Storage.Retry(id, at, reason)
+}
+
+func (s *Scheduler) Reschedule(id string, at time.Time) error {
+	defer s.notify()
+	return s.Storage.Reschedule(id, at)
+}
+
+func (s *Scheduler) Update(id string, change func(r *Reminder)) error {
+	defer s.notify()
+	return s.Storage.Update(id, change)
+}
+
+func (s *Scheduler) Delete(id string) error {
+	defer s.notify()
+	return s.Storage.Delete(id)
+}
+
+// notify будит цикл Run, не блокируясь, если он уже разбужен
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run спит до ближайшего напоминания и вызывает fire, когда оно сработало.
+// Возвращается после отмены ctx, дождавшись завершения текущего fire.
+func (s *Scheduler) Run(ctx context.Context, fire func(now time.Time)) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-timer.C:
+			fire(now)
+		case <-s.wake:
+		}
+
+		// Останавливаем таймер и вычитываем возможное срабатывание, чтобы Reset не
+		// оставил в канале устаревшее значение
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(s.sleepFor(time.Now()))
+	}
+}
+
+// sleepFor вычисляет паузу до ближайшего напоминания, но не дольше maxIdle
+func (s *Scheduler) sleepFor(now time.Time) time.Duration {
+	next, ok := s.NextDue()
+	if !ok {
+		return maxIdle
+	}
+	return min(max(next.Sub(now), 0), maxIdle)
+}
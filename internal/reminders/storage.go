@@ -1,77 +1,207 @@
 package reminders
 
 import (
+	"container/heap"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
 	"sync"
 	"time"
 )
 
+// Repeat — правило повторения напоминания
+type Repeat string
+
+const (
+	RepeatNone   Repeat = ""       // однократное напоминание
+	RepeatSunset Repeat = "sunset" // каждый день относительно заката (см. Offset)
+	RepeatDaily   Repeat = "daily"   // каждый день в то же время
+	RepeatWeekly  Repeat = "weekly"  // каждую неделю
+	RepeatMonthly Repeat = "monthly" // каждый месяц в тот же день
+	RepeatYearly  Repeat = "yearly"  // каждый год
+)
+
+// Advance возвращает следующее срабатывание календарного правила после t.
+// day — день месяца, на который приходятся ежемесячные и ежегодные напоминания
+// (0 — день из t); в коротких месяцах берётся их последний день: 31 января →
+// 28 февраля → 31 марта. Для RepeatNone и RepeatSunset (закат зависит от
+// прогноза) возвращает false.
+func (r Repeat) Advance(t time.Time, day int) (time.Time, bool) {
+	switch r {
+	case RepeatDaily:
+		return t.AddDate(0, 0, 1), true
+	case RepeatWeekly:
+		return t.AddDate(0, 0, 7), true
+	case RepeatMonthly:
+		return addMonths(t, 1, day), true
+	case RepeatYearly:
+		return addMonths(t, 12, day), true
+	}
+	return t, false
+}
+
+// addMonths сдвигает t на n месяцев и ставит день day, но не дальше конца месяца
+func addMonths(t time.Time, n int, day int) time.Time {
+	if day <= 0 {
+		day = t.Day()
+	}
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day, last)-1)
+}
+
+// State — состояние доставки напоминания
+type State string
+
+const (
+	StatePending State = ""     // ждёт своего времени или повторной попытки
+	StateDead    State = "dead" // доставить невозможно (бот заблокирован и т.п.)
+)
+
+// ErrNotFound возвращается, если напоминания с таким ID нет
+var ErrNotFound = errors.New("напоминание не найдено")
+
 type Reminder struct {
+	ID       string    // уникальный идентификатор, назначается при добавлении
 	ChatID   int64     
 	Text     string
 	Time     time.Time
+	Repeat   Repeat        // повторение; пусто — однократное
+	Offset   time.Duration // для RepeatSunset: смещение относительно заката (отрицательное — до заката)
+	Day      int           // для RepeatMonthly и RepeatYearly: день месяца из первого срабатывания
+	AuthorID int64         // кто создал напоминание (0 — неизвестно)
+	Mention  string        // кого упомянуть при доставке в группе, например "@ivan"
+	SourceChatID    int64  // чат сообщения, на которое ответили командой /remind
+	SourceMessageID int    // это сообщение; 0 — напоминание создано без ответа
+	CalendarHref    string // объект в CalDAV-календаре, с которым синхронизировано напоминание
+	CalendarETag    string // ETag этого объекта на момент последней синхронизации
+	UID             string // UID события календаря, из которого создано напоминание
+
+	State      State     // состояние доставки
+	Attempts   int       // сколько раз пытались отправить
+	LeaseUntil time.Time // до этого момента напоминание захвачено отправителем или ждёт повтора
+	LastError  string    // причина последней неудачной отправки
 }
 
+// Хранилище работает по схеме «захват/подтверждение»: Claim выдаёт сработавшие
+// напоминания на время отправки, и только Ack удаляет их. Если отправитель упал,
+// захват истекает и напоминание будет выдано снова (доставка «хотя бы один раз»).
 type Storage interface {
-	Add( rem Reminder ) error            // добавить напоминание
-	FetchDue( now time.Time ) []Reminder // получить все “сработавшие” (due) напоминания
-	Delete( rem Reminder ) error         // удалить конкретное напоминание
+	Add( rem Reminder ) error                               // добавить напоминание (ID назначается автоматически)
+	Claim( now time.Time, lease time.Duration ) []Reminder  // захватить “сработавшие” (due) напоминания на время отправки
+	NextDue() ( time.Time, bool )                           // когда сработает ближайшее напоминание; false — их нет
+	Ack( id string ) error                                  // напоминание доставлено — удалить
+	Retry( id string, at time.Time, reason string ) error   // временная ошибка — повторить не раньше at
+	Reschedule( id string, at time.Time ) error             // перенести повторяющееся напоминание на следующий раз
+	Fail( id string, reason string ) error                  // постоянная ошибка — перевести в «недоставленные»
+	Update( id string, change func( r *Reminder ) ) error  // изменить напоминание (текст, время, связь с календарём)
+	Delete( id string ) error                               // удалить конкретное напоминание
 	ListAll() []Reminder               // (опционально) получить все напоминания (для отладки)
+	Close() error                          // сбросить несохранённые данные перед остановкой
 }
 
 type memoryStorage struct {
-	mu        sync.Mutex
-	reminders []Reminder
+	mu    sync.Mutex
+	items map[string]*queueItem
+	queue dueQueue // ожидающие напоминания, упорядоченные по времени срабатывания
 }
 
 // NewMemoryStorage создаёт новый экземпляр in-memory хранилища
 func NewMemoryStorage() Storage {
 	return &memoryStorage{
-		reminders: make([]Reminder, 0, 10),
+		items: make(map[string]*queueItem),
 	}
 }
 
 func (m *memoryStorage) Add(rem Reminder) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.reminders = append(m.reminders, rem)
+
+	rem = prepareNew(rem)
+
+	// Повторное добавление с тем же ID заменяет напоминание, а не кладёт в кучу второе
+	if item, ok := m.items[rem.ID]; ok {
+		item.rem = rem
+		if item.index >= 0 {
+			heap.Fix(&m.queue, item.index)
+		} else {
+			heap.Push(&m.queue, item)
+		}
+		return nil
+	}
+
+	item := &queueItem{rem: rem}
+	m.items[rem.ID] = item
+	heap.Push(&m.queue, item)
 	return nil
 }
 
-func (m *memoryStorage) FetchDue(now time.Time) []Reminder {
+// Claim снимает с вершины кучи сработавшие напоминания, не просматривая остальные
+func (m *memoryStorage) Claim(now time.Time, lease time.Duration) []Reminder {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	var due []Reminder
-	var remain []Reminder
-	// Разделяем на “сработавшие” и “остающиеся”
-	for _, r := range m.reminders {
-		if !now.Before(r.Time) {
-			due = append(due, r)
-		} else {
-			remain = append(remain, r)
-		}
+	var claimed []*queueItem
+	for len(m.queue) > 0 && !now.Before(m.queue[0].dueAt()) {
+		item := heap.Pop(&m.queue).(*queueItem)
+		item.rem.Attempts++
+		item.rem.LeaseUntil = now.Add(lease)
+		claimed = append(claimed, item)
+		due = append(due, item.rem)
+	}
+	// Захваченные возвращаются в кучу только после обхода: при lease <= 0
+	// они остались бы на вершине, и цикл никогда не закончился бы
+	for _, item := range claimed {
+		heap.Push(&m.queue, item)
 	}
-	// Сохраняем только “остающиеся”
-	m.reminders = remain
 	return due
 }
 
-// Delete удаляет конкретное напоминание (по совпадению всех полей)
-// Однако в нашей логике удаление осуществляется в FetchDue, поэтому
-// метод Delete используем только, если нужно “ручное” удаление.
-func (m *memoryStorage) Delete(rem Reminder) error {
+func (m *memoryStorage) NextDue() (time.Time, bool) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	var newSlice []Reminder
-	for _, r := range m.reminders {
-		// сравним точное совпадение полей
-		if r.ChatID == rem.ChatID && r.Text == rem.Text && r.Time.Equal(rem.Time) {
-			continue // пропускаем “удаляемое”
-		}
-		newSlice = append(newSlice, r)
+	if len(m.queue) == 0 {
+		return time.Time{}, false
+	}
+	return m.queue[0].dueAt(), true
+}
+
+func (m *memoryStorage) Ack(id string) error {
+	return m.Delete(id)
+}
+
+func (m *memoryStorage) Retry(id string, at time.Time, reason string) error {
+	return m.modify(id, func(r *Reminder) { markRetry(r, at, reason) })
+}
+
+func (m *memoryStorage) Reschedule(id string, at time.Time) error {
+	return m.modify(id, func(r *Reminder) { markRescheduled(r, at) })
+}
+
+func (m *memoryStorage) Fail(id string, reason string) error {
+	return m.modify(id, func(r *Reminder) { markDead(r, reason) })
+}
+
+func (m *memoryStorage) Update(id string, change func(r *Reminder)) error {
+	return m.modify(id, change)
+}
+
+// Delete удаляет конкретное напоминание по ID
+func (m *memoryStorage) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.items[id]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(m.items, id)
+	if item.index >= 0 {
+		heap.Remove(&m.queue, item.index)
 	}
-	m.reminders = newSlice
 	return nil
 }
 
@@ -80,8 +210,135 @@ func (m *memoryStorage) ListAll() []Reminder {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Возвращаем копию, чтобы никто не менял внутренний slice извне
-	copySlice := make([]Reminder, len(m.reminders))
-	copy(copySlice, m.reminders)
-	return copySlice
-}
\ No newline at end of file
+	// Возвращаем копии, чтобы никто не менял внутренние данные извне
+	list := make([]Reminder, 0, len(m.items))
+	for _, item := range m.items {
+		list = append(list, item.rem)
+	}
+	return list
+}
+
+// Close ничего не делает: in-memory хранилищу нечего сбрасывать
+func (m *memoryStorage) Close() error {
+	return nil
+}
+
+// modify меняет напоминание и восстанавливает порядок кучи:
+// недоставленные из неё убираются, остальные переставляются по новому времени
+func (m *memoryStorage) modify(id string, change func(r *Reminder)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.items[id]
+	if !ok {
+		return ErrNotFound
+	}
+	change(&item.rem)
+
+	switch {
+	case item.rem.State == StateDead && item.index >= 0:
+		heap.Remove(&m.queue, item.index)
+	case item.rem.State != StateDead && item.index < 0:
+		heap.Push(&m.queue, item)
+	case item.index >= 0:
+		heap.Fix(&m.queue, item.index)
+	}
+	return nil
+}
+
+// queueItem — напоминание в куче с позицией для heap.Fix/heap.Remove
+type queueItem struct {
+	rem   Reminder
+	index int // -1 — элемент не в куче
+}
+
+// dueAt — момент, когда напоминание можно захватить: его время или конец захвата/паузы
+func (it *queueItem) dueAt() time.Time {
+	return dueAt(it.rem)
+}
+
+func dueAt(r Reminder) time.Time {
+	if r.LeaseUntil.After(r.Time) {
+		return r.LeaseUntil
+	}
+	return r.Time
+}
+
+// dueQueue — min-куча по dueAt (container/heap)
+type dueQueue []*queueItem
+
+func (q dueQueue) Len() int           { return len(q) }
+func (q dueQueue) Less(i, j int) bool { return q[i].dueAt().Before(q[j].dueAt()) }
+func (q dueQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *dueQueue) Push(x any) {
+	item := x.(*queueItem)
+	item.index = len(*q)
+	*q = append(*q, item)
+}
+
+func (q *dueQueue) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*q = old[:n-1]
+	return item
+}
+
+// ========================================
+// Общая логика очереди для всех реализаций
+// ========================================
+
+// newID генерирует случайный идентификатор напоминания
+func newID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand не должен отказывать; на всякий случай — время в наносекундах
+		return hex.EncodeToString([]byte(time.Now().Format(time.RFC3339Nano)))
+	}
+	return hex.EncodeToString(buf)
+}
+
+// prepareNew назначает ID и сбрасывает служебные поля нового напоминания
+func prepareNew(rem Reminder) Reminder {
+	if rem.ID == "" {
+		rem.ID = newID()
+	}
+	if rem.Day == 0 && (rem.Repeat == RepeatMonthly || rem.Repeat == RepeatYearly) {
+		rem.Day = rem.Time.Day()
+	}
+	rem.State = StatePending
+	rem.Attempts = 0
+	rem.LeaseUntil = time.Time{}
+	rem.LastError = ""
+	return rem
+}
+
+func markRetry(r *Reminder, at time.Time, reason string) {
+	r.LeaseUntil = at
+	r.LastError = reason
+}
+
+func markRescheduled(r *Reminder, at time.Time) {
+	r.Time = at
+	r.Attempts = 0
+	r.LeaseUntil = time.Time{}
+	r.LastError = ""
+}
+
+func markDead(r *Reminder, reason string) {
+	r.State = StateDead
+	r.LeaseUntil = time.Time{}
+	r.LastError = reason
+}
+
+// logStorageError логирует ошибки методов, которые не возвращают error
+func logStorageError(op string, err error) {
+	log.Printf("Ошибка хранилища напоминаний (%s): %v", op, err)
+}
@@ -0,0 +1,172 @@
+package reminders
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"tg-bot/internal/kv"
+)
+
+// storages возвращает обе реализации хранилища: в памяти и поверх kv.Store
+func storages(t *testing.T) map[string]Storage {
+	t.Helper()
+	store, err := kv.NewFileStore(filepath.Join(t.TempDir(), "kv.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]Storage{"memory": NewMemoryStorage(), "kv": NewKVStorage(store)}
+}
+
+func TestStorageAddSameIDReplaces(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	for name, s := range storages(t) {
+		t.Run(name, func(t *testing.T) {
+			if err := s.Add(Reminder{ID: "a", Text: "раньше", Time: now.Add(-time.Minute)}); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Add(Reminder{ID: "a", Text: "позже", Time: now.Add(time.Hour)}); err != nil {
+				t.Fatal(err)
+			}
+
+			if due := s.Claim(now, time.Minute); len(due) != 0 {
+				t.Fatalf("сработал призрак заменённого напоминания: %+v", due)
+			}
+			if next, ok := s.NextDue(); !ok || !next.Equal(now.Add(time.Hour)) {
+				t.Errorf("NextDue = %v, %v; ожидалось %v", next, ok, now.Add(time.Hour))
+			}
+			if all := s.ListAll(); len(all) != 1 || all[0].Text != "позже" {
+				t.Errorf("ListAll = %+v", all)
+			}
+		})
+	}
+}
+
+func TestStorageClaimLifecycle(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	for name, s := range storages(t) {
+		t.Run(name, func(t *testing.T) {
+			for _, r := range []Reminder{
+				{ID: "late", Time: now.Add(time.Hour)},
+				{ID: "due", Time: now.Add(-time.Second)},
+				{ID: "dead", Time: now.Add(-time.Minute)},
+			} {
+				if err := s.Add(r); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := s.Fail("dead", "бот заблокирован"); err != nil {
+				t.Fatal(err)
+			}
+
+			due := s.Claim(now, time.Minute)
+			if len(due) != 1 || due[0].ID != "due" || due[0].Attempts != 1 {
+				t.Fatalf("Claim = %+v, ожидалось только due", due)
+			}
+			// Захваченное напоминание не выдаётся повторно, пока не истёк захват
+			if again := s.Claim(now.Add(time.Second), time.Minute); len(again) != 0 {
+				t.Fatalf("повторный Claim = %+v", again)
+			}
+			if next, _ := s.NextDue(); !next.Equal(now.Add(time.Minute)) {
+				t.Errorf("NextDue = %v, ожидался конец захвата %v", next, now.Add(time.Minute))
+			}
+			// После истечения захвата — снова выдаётся (доставка «хотя бы один раз»)
+			if again := s.Claim(now.Add(2*time.Minute), time.Minute); len(again) != 1 || again[0].Attempts != 2 {
+				t.Fatalf("Claim после истечения захвата = %+v", again)
+			}
+
+			if err := s.Ack("due"); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Ack("due"); err != ErrNotFound {
+				t.Errorf("повторный Ack = %v, ожидалось ErrNotFound", err)
+			}
+			if next, _ := s.NextDue(); !next.Equal(now.Add(time.Hour)) {
+				t.Errorf("NextDue = %v, ожидалось %v", next, now.Add(time.Hour))
+			}
+			if all := s.ListAll(); len(all) != 2 {
+				t.Errorf("ListAll вернул %d напоминаний, ожидалось 2 (late и dead)", len(all))
+			}
+		})
+	}
+}
+
+func TestStorageClaimWithoutLease(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	for name, s := range storages(t) {
+		t.Run(name, func(t *testing.T) {
+			for _, id := range []string{"a", "b"} {
+				if err := s.Add(Reminder{ID: id, Time: now.Add(-time.Second)}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// Без захвата Claim выдаёт каждое напоминание один раз за вызов и завершается
+			done := make(chan []Reminder, 1)
+			go func() { done <- s.Claim(now, 0) }()
+			select {
+			case due := <-done:
+				if len(due) != 2 {
+					t.Fatalf("Claim = %+v, ожидалось два напоминания", due)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Claim с нулевым захватом не завершился")
+			}
+
+			// И сразу выдаёт их снова: захвата нет
+			if again := s.Claim(now, -time.Minute); len(again) != 2 || again[0].Attempts != 2 {
+				t.Fatalf("повторный Claim = %+v", again)
+			}
+		})
+	}
+}
+
+func TestRepeatAdvance(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 9, 30, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name   string
+		repeat Repeat
+		day    int
+		from   time.Time
+		want   []time.Time
+	}{
+		{"ежедневно", RepeatDaily, 0, date(2025, 2, 27), []time.Time{date(2025, 2, 28), date(2025, 3, 1)}},
+		{"еженедельно", RepeatWeekly, 0, date(2025, 12, 29), []time.Time{date(2026, 1, 5)}},
+		{"31-е число", RepeatMonthly, 31, date(2025, 1, 31),
+			[]time.Time{date(2025, 2, 28), date(2025, 3, 31), date(2025, 4, 30), date(2025, 5, 31)}},
+		{"30-е в високосный год", RepeatMonthly, 30, date(2024, 1, 30), []time.Time{date(2024, 2, 29), date(2024, 3, 30)}},
+		{"день по умолчанию из t", RepeatMonthly, 0, date(2025, 1, 15), []time.Time{date(2025, 2, 15)}},
+		{"через конец года", RepeatMonthly, 31, date(2025, 12, 31), []time.Time{date(2026, 1, 31)}},
+		{"29 февраля", RepeatYearly, 29, date(2024, 2, 29),
+			[]time.Time{date(2025, 2, 28), date(2026, 2, 28), date(2027, 2, 28), date(2028, 2, 29)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at := tt.from
+			for i, want := range tt.want {
+				next, ok := tt.repeat.Advance(at, tt.day)
+				if !ok || !next.Equal(want) {
+					t.Fatalf("шаг %d: Advance(%v) = %v, %v; ожидалось %v", i+1, at, next, ok, want)
+				}
+				at = next
+			}
+		})
+	}
+
+	for _, r := range []Repeat{RepeatNone, RepeatSunset} {
+		if _, ok := r.Advance(date(2025, 1, 1), 0); ok {
+			t.Errorf("Advance для %q не должен вычислять следующее срабатывание", r)
+		}
+	}
+}
+
+func TestPrepareNewAnchorsMonthlyDay(t *testing.T) {
+	rem := prepareNew(Reminder{Repeat: RepeatMonthly, Time: time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC)})
+	if rem.Day != 31 {
+		t.Errorf("Day = %d, ожидалось 31", rem.Day)
+	}
+	if rem := prepareNew(Reminder{Repeat: RepeatWeekly, Time: time.Now()}); rem.Day != 0 {
+		t.Errorf("Day = %d у еженедельного напоминания", rem.Day)
+	}
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	airTTL      = 30 * time.Minute // данные о загрязнении обновляются примерно раз в час
+	airStaleFor = 3 * time.Hour
+)
+
+// AirPollution — текущие показатели качества воздуха (OpenWeather Air Pollution API)
+type AirPollution struct {
+	AQI  int     // индекс качества воздуха: 1 — хорошо … 5 — очень плохо
+	PM25 float64 // мелкодисперсные частицы PM2.5, мкг/м³
+	PM10 float64 // частицы PM10, мкг/м³
+	O3   float64 // озон, мкг/м³
+	Dt   int64   // время замера
+}
+
+type airPollutionRes struct {
+	List []struct {
+		Dt   int64 `json:"dt"`
+		Main struct {
+			AQI int `json:"aqi"`
+		} `json:"main"`
+		Components struct {
+			O3   float64 `json:"o3"`
+			PM25 float64 `json:"pm2_5"`
+			PM10 float64 `json:"pm10"`
+		} `json:"components"`
+	} `json:"list"`
+}
+
+// GetAirPollution возвращает качество воздуха для заданных координат.
+// Запросы к Air Pollution API не входят в лимит OneCall, но тоже кэшируются.
+func (s *WeatherService) GetAirPollution(lat string, lon string) (AirPollution, error) {
+	lat, lon, err := roundCoords( lat, lon )
+	if err != nil {
+		return AirPollution{}, err
+	}
+
+	return s.airCache.Get( lat+","+lon, func() (AirPollution, time.Time, error) {
+		air, err := s.fetchAirPollution( lat, lon )
+		return air, time.Now().Add( airTTL ), err
+	})
+}
+
+func (s *WeatherService) fetchAirPollution(lat string, lon string) (AirPollution, error) {
+	var data airPollutionRes
+
+	url := "https://api.openweathermap.org/data/2.5/air_pollution"
+	res, err := s.client.R().SetQueryParam( "lat", lat ).SetQueryParam( "lon", lon ).SetQueryParam( "appid", s.apiKey ).SetResult( &data ).Get( url )
+	if err != nil {
+		return AirPollution{}, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	if res.IsError() {
+		return AirPollution{}, fmt.Errorf("API вернул статус %s", res.Status())
+	}
+	if len( data.List ) == 0 {
+		return AirPollution{}, fmt.Errorf("API не вернул данных о качестве воздуха")
+	}
+
+	cur := data.List[0]
+	return AirPollution{
+		AQI:  cur.Main.AQI,
+		PM25: cur.Components.PM25,
+		PM10: cur.Components.PM10,
+		O3:   cur.Components.O3,
+		Dt:   cur.Dt,
+	}, nil
+}
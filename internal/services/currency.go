@@ -4,11 +4,28 @@ import (
 	"fmt"
 	"time"
 	resty "resty.dev/v3"
+
+	"tg-bot/internal/cache"
 )
 
 
 type CurrencyService struct {
 	client *resty.Client
+	cache  *cache.Cache[Rate]
+}
+
+// Rate — официальный курс Нацбанка: Official BYN за Scale единиц валюты
+type Rate struct {
+	Official float64
+	Scale    int
+}
+
+// PerUnit возвращает курс за одну единицу валюты
+func (r Rate) PerUnit() float64 {
+	if r.Scale <= 0 {
+		return r.Official
+	}
+	return r.Official / float64(r.Scale)
 }
 
 
@@ -18,37 +35,76 @@ const (
 	USD CurrencyType = "USD"
 	EUR CurrencyType = "EUR"
 	RUB CurrencyType = "RUB"
+	BYN CurrencyType = "BYN" // национальная валюта: курс всегда 1
 )
 
+// Нацбанк устанавливает официальный курс один раз на календарный день,
+// поэтому курс кэшируется до ближайшей полуночи по Минску
+const currencyStaleFor = 7 * 24 * time.Hour
+
+var nbrbLocation = loadNBRBLocation()
+
+func loadNBRBLocation() *time.Location {
+	loc, err := time.LoadLocation( "Europe/Minsk" )
+	if err != nil {
+		return time.FixedZone( "Europe/Minsk", 3*60*60 )
+	}
+	return loc
+}
+
 func NewCurrencyService() *CurrencyService {
 	return &CurrencyService{
 		client: resty.New().SetTimeout( 5 * time.Second ).SetRetryCount( 1 ),
+		cache:  cache.New[Rate]( currencyStaleFor ),
 	}
 }
 
 func (s *CurrencyService) GetCurrency(t CurrencyType) ( float64, error ) {
+	rate, err := s.GetRate( t )
+	return rate.Official, err
+}
+
+// GetRate возвращает официальный курс вместе с количеством единиц, за которое он указан
+// (например, курс российского рубля — за 100 RUB)
+func (s *CurrencyService) GetRate(t CurrencyType) ( Rate, error ) {
 
 	switch t {
     case USD, EUR, RUB:
+    case BYN:
+			return Rate{ Official: 1, Scale: 1 }, nil
     default:
-			return 0.0, fmt.Errorf( "неизвестная валюта: %v", t )
+			return Rate{}, fmt.Errorf( "неизвестная валюта: %v", t )
   }
 
+	return s.cache.Get( string( t ), func() ( Rate, time.Time, error ) {
+		rate, err := s.fetchCurrency( t )
+		return rate, nextNBRBPublication( time.Now() ), err
+	})
+}
+
+// fetchCurrency запрашивает официальный курс у Нацбанка
+func (s *CurrencyService) fetchCurrency(t CurrencyType) ( Rate, error ) {
 	url := fmt.Sprintf( "https://api.nbrb.by/exrates/rates/%v?parammode=2", t  )
 
 	var data struct {
+		CurScale        int     `json:"Cur_Scale"`
 		CurOfficialRate float64 `json:"Cur_OfficialRate"`
 	}
 
 	res, err := s.client.R().SetResult( &data ).Get( url )
 	if err != nil {
-		return 0.0, fmt.Errorf( "ошибка выполнения запроса: %v", err )
+		return Rate{}, fmt.Errorf( "ошибка выполнения запроса: %v", err )
 	}
 
 	if res.IsError() {
-		return 0.0, fmt.Errorf( "сейчас почему-то не получается получить данные об курсе, код ошибки: %v", res.Status()  )
+		return Rate{}, fmt.Errorf( "сейчас почему-то не получается получить данные об курсе, код ошибки: %v", res.Status()  )
 	}
 
-	return data.CurOfficialRate, nil
+	return Rate{ Official: data.CurOfficialRate, Scale: data.CurScale }, nil
 }
 
+// nextNBRBPublication возвращает момент, с которого действует следующий официальный курс
+func nextNBRBPublication(now time.Time) time.Time {
+	local := now.In( nbrbLocation )
+	return time.Date( local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, nbrbLocation )
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	placeTTL      = 24 * time.Hour // города не переезжают — кэшируем надолго
+	placeStaleFor = 7 * 24 * time.Hour
+)
+
+// ErrPlaceNotFound — по названию не нашлось ни одного места
+var ErrPlaceNotFound = errors.New("место не найдено")
+
+// Place — место, найденное по названию (OpenWeather Geocoding API)
+type Place struct {
+	Name    string // название на запрошенном языке, если OpenWeather его знает
+	Country string // код страны: BY, LT…
+	Lat     string
+	Lon     string
+}
+
+type placeRes []struct {
+	Name       string            `json:"name"`
+	LocalNames map[string]string `json:"local_names"`
+	Country    string            `json:"country"`
+	Lat        float64           `json:"lat"`
+	Lon        float64           `json:"lon"`
+}
+
+// FindPlace ищет место по названию. Запросы к Geocoding API не входят в лимит OneCall,
+// но кэшируются: одни и те же города спрашивают постоянно.
+func (s *WeatherService) FindPlace(query string, lang string) (Place, error) {
+	query = strings.TrimSpace( query )
+	if query == "" {
+		return Place{}, ErrPlaceNotFound
+	}
+	key := strings.ToLower( query ) + "|" + lang
+
+	return s.placeCache.Get( key, func() (Place, time.Time, error) {
+		place, err := s.fetchPlace( query, lang )
+		return place, time.Now().Add( placeTTL ), err
+	})
+}
+
+func (s *WeatherService) fetchPlace(query string, lang string) (Place, error) {
+	var data placeRes
+
+	url := "https://api.openweathermap.org/geo/1.0/direct"
+	res, err := s.client.R().SetQueryParam( "q", query ).SetQueryParam( "limit", "1" ).SetQueryParam( "appid", s.apiKey ).SetResult( &data ).Get( url )
+	if err != nil {
+		return Place{}, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	if res.IsError() {
+		return Place{}, fmt.Errorf("API вернул статус %s", res.Status())
+	}
+	if len( data ) == 0 {
+		return Place{}, ErrPlaceNotFound
+	}
+
+	found := data[0]
+	name := found.Name
+	if local := found.LocalNames[lang]; local != "" {
+		name = local
+	}
+
+	return Place{
+		Name:    name,
+		Country: found.Country,
+		Lat:     strconv.FormatFloat( found.Lat, 'f', 6, 64 ),
+		Lon:     strconv.FormatFloat( found.Lon, 'f', 6, 64 ),
+	}, nil
+}
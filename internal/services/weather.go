@@ -3,19 +3,34 @@ package services
 import (
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
+	"sync"
 	"time"
 
 	resty "resty.dev/v3"
+
+	"tg-bot/internal/cache"
 )
 
 const maxDaily = 999
 
+const (
+	weatherTTL      = 10 * time.Minute // погода меняется не так быстро, чтобы ходить в API на каждое нажатие
+	weatherStaleFor = 3 * time.Hour    // столько можно показывать старый прогноз, пока он обновляется или API недоступен
+)
+
 type WeatherService struct {
 	client *resty.Client
 	apiKey   string
 	location *time.Location
-	day      string
-	requestCount  int
+	cache    *cache.Cache[[]byte]
+	airCache *cache.Cache[AirPollution]
+	placeCache *cache.Cache[Place]
+
+	mu           sync.Mutex // защищает счётчик запросов: кэш вызывает загрузку из разных горутин
+	day          string
+	requestCount int
 }
 
 // NewWeatherService создаёт новый экземпляр
@@ -24,35 +39,114 @@ func NewWeatherService(apiKey string, loc *time.Location) *WeatherService {
 		apiKey:   apiKey,
 		location: loc,
 		client: resty.New().SetTimeout( 5 * time.Second ).SetRetryCount( 1 ),
+		cache:    cache.New[[]byte]( weatherStaleFor ),
+		airCache: cache.New[AirPollution]( airStaleFor ),
+		placeCache: cache.New[Place]( placeStaleFor ),
+	}
+}
+
+// GetWeather возвращает ответ OneCall API (без поминутного и почасового прогноза
+// и предупреждений) для заданных координат. Координаты округляются (~1 км): по ним
+// и запрашивается погода, и кэшируется ответ, поэтому соседние точки и повторные
+// нажатия кнопки не расходуют дневной лимит запросов.
+func (s *WeatherService) GetWeather(lat string, lon string, units string, lang string) ([]byte, error) {
+	units, lang = weatherParams( units, lang )
+	lat, lon, err := roundCoords( lat, lon )
+	if err != nil {
+		return nil, err
+	}
+
+	return s.cache.Get( weatherKey( lat, lon, units, lang ), func() ([]byte, time.Time, error) {
+		body, err := s.fetchWeather( lat, lon, units, lang )
+		return body, time.Now().Add( weatherTTL ), err
+	})
+}
+
+// HasWeather сообщает, есть ли в кэше ответ OneCall для этих координат, — тогда
+// GetWeather отдаст его сразу, не дожидаясь API
+func (s *WeatherService) HasWeather(lat string, lon string, units string, lang string) bool {
+	units, lang = weatherParams( units, lang )
+	lat, lon, err := roundCoords( lat, lon )
+	if err != nil {
+		return false
 	}
+	_, ok := s.cache.Peek( weatherKey( lat, lon, units, lang ) )
+	return ok
 }
 
-// Forecast возвращает описание погоды (строка) и температуру (°C) для заданного города
-func (s *WeatherService) GetWeather(lat string, lon string, exclude string, units string) ([]byte, error) {
+// weatherParams подставляет единицы и язык по умолчанию
+func weatherParams(units string, lang string) (string, string) {
 	if units == "" {
 		units = "metric"
 	}
+	return units, weatherLang( lang )
+}
+
+// weatherKey — ключ кэша ответа OneCall для округлённых координат
+func weatherKey(lat string, lon string, units string, lang string) string {
+	return lat + "," + lon + "|" + units + "|" + lang
+}
+
+// fetchWeather выполняет реальный запрос к OneCall API с учётом дневного лимита;
+// координаты уже округлены (см. roundCoords)
+func (s *WeatherService) fetchWeather(lat string, lon string, units string, lang string) ([]byte, error) {
+	if err := s.takeQuota(); err != nil {
+		return nil, err
+	}
+
+	url := "https://api.openweathermap.org/data/3.0/onecall"
+	res, err := s.client.R().SetQueryParam( "lat", lat ).SetQueryParam( "lon", lon ).SetQueryParam( "appid", s.apiKey ).SetQueryParam("exclude", "minutely,hourly,alerts").SetQueryParam("units", units).SetQueryParam( "lang", lang ).Get( url )
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("API вернул статус %s", res.Status())
+	}
+
+	return res.Bytes(), nil
+}
+
+// weatherLang выбирает язык описаний погоды. Белорусского OpenWeather не знает —
+// для него, как и по умолчанию, описания приходят по-русски.
+func weatherLang(lang string) string {
+	switch lang {
+	case "en":
+		return "en"
+	default:
+		return "ru"
+	}
+}
+
+// takeQuota учитывает запрос в дневном лимите
+func (s *WeatherService) takeQuota() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
 	today := time.Now().Format( time.DateOnly )
 
 	if today != s.day {
 		s.requestCount = 0
-		s.day = time.Now().Format( time.DateOnly )
+		s.day = today
 	}
 
 	if s.requestCount >= maxDaily {
-		return nil, errors.New("достигнут дневной лимит запросов (999)")
+		return errors.New("достигнут дневной лимит запросов (999)")
 	}
 	s.requestCount++
+	return nil
+}
 
-	url := "https://api.openweathermap.org/data/3.0/onecall"
-	res, err := s.client.R().SetQueryParam( "lat", lat ).SetQueryParam( "lon", lat ).SetQueryParam( "appid", s.apiKey ).SetQueryParam( "appid", s.apiKey ).SetQueryParam("exclude", "minutely,hourly,alerts").SetQueryParam("units", units).SetQueryParam( "lang","ru" ).Get( url )
+// roundCoords округляет координаты до сотых градуса: с ними идут и запросы к API,
+// и ключи кэша, чтобы закэшированный ответ точно соответствовал своему ключу
+func roundCoords(lat string, lon string) (string, string, error) {
+	latF, err := strconv.ParseFloat( lat, 64 )
 	if err != nil {
-		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+		return "", "", fmt.Errorf( "некорректная широта %q: %v", lat, err )
 	}
-	if res.IsError() {
-		return nil, fmt.Errorf("API вернул статус %s", res.Status())
+	lonF, err := strconv.ParseFloat( lon, 64 )
+	if err != nil {
+		return "", "", fmt.Errorf( "некорректная долгота %q: %v", lon, err )
 	}
 
-	return res.Bytes(), nil
+	return fmt.Sprintf( "%.2f", math.Round( latF*100 )/100 ), fmt.Sprintf( "%.2f", math.Round( lonF*100 )/100 ), nil
 }
@@ -0,0 +1,214 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"tg-bot/internal/kv"
+)
+
+const (
+	kvPrefix   = "settings:"     // + ID чата: настройки чата
+	kvAllKey   = "settings:all"  // ID всех чатов с сохранёнными настройками
+	kvLockTTL  = 10 * time.Second
+	kvLockWait = 5 * time.Second
+
+	kvSecretPrefix = "settings:caldav:" // + ID чата: пароль календаря, отдельно от настроек
+	kvUserPrefix   = "settings:user:"   // + @username в нижнем регистре: пользователь с этим именем
+)
+
+// kvStorage хранит настройки каждого чата под своим ключом, а ID чатов —
+// в множестве kvAllKey для ListAll. Чтение-изменение-запись (Update) идёт под
+// блокировкой чата: экземпляры бота не затирают изменения друг друга и не ждут
+// изменений в других чатах. Пароль календаря в настройки не попадает: у каждого
+// чата для него свой ключ.
+type kvStorage struct {
+	store kv.Store
+}
+
+// NewKVStorage создаёт хранилище настроек поверх постоянного kv.Store
+func NewKVStorage(store kv.Store) Storage {
+	return &kvStorage{store: store}
+}
+
+func settingsKey(chatID int64) string {
+	return kvPrefix + strconv.FormatInt(chatID, 10)
+}
+
+func lockKey(chatID int64) string {
+	return settingsKey(chatID) + ":lock"
+}
+
+func secretKey(chatID int64) string {
+	return kvSecretPrefix + strconv.FormatInt(chatID, 10)
+}
+
+// load читает настройки чата вместе с паролем календаря
+func (s *kvStorage) load(chatID int64) (Settings, error) {
+	raw, err := s.store.Get(settingsKey(chatID))
+	if errors.Is(err, kv.ErrNotFound) {
+		return Settings{ChatID: chatID}, nil
+	}
+	if err != nil {
+		return Settings{ChatID: chatID}, err
+	}
+
+	var st Settings
+	if err := json.Unmarshal(raw, &st); err != nil {
+		return Settings{ChatID: chatID}, err
+	}
+	if st.CalDAV != nil {
+		if st.CalDAV.Password, err = s.password(chatID); err != nil {
+			return Settings{ChatID: chatID}, err
+		}
+	}
+	return st, nil
+}
+
+func (s *kvStorage) Get(chatID int64) (Settings, error) {
+	return s.load(chatID)
+}
+
+// password читает пароль календаря чата из его ключа
+func (s *kvStorage) password(chatID int64) (string, error) {
+	secret, err := s.store.Get(secretKey(chatID))
+	if errors.Is(err, kv.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(secret), nil
+}
+
+func (s *kvStorage) Save(st Settings) error {
+	return kv.WithLock(s.store, lockKey(st.ChatID), kvLockTTL, kvLockWait, func() error {
+		return s.save(st)
+	})
+}
+
+// Update читает, изменяет и сохраняет настройки чата под его блокировкой,
+// поэтому одновременные изменения разных обработчиков и экземпляров не теряются
+func (s *kvStorage) Update(chatID int64, change func(st *Settings)) error {
+	return kv.WithLock(s.store, lockKey(chatID), kvLockTTL, kvLockWait, func() error {
+		st, err := s.load(chatID)
+		if err != nil {
+			return err
+		}
+		change(&st)
+		return s.save(st)
+	})
+}
+
+// save записывает настройки чата st и добавляет чат в kvAllKey;
+// вызывается под блокировкой чата
+func (s *kvStorage) save(st Settings) error {
+	if err := s.saveSecret(st); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	if err := s.store.Set(settingsKey(st.ChatID), raw); err != nil {
+		return err
+	}
+	return s.store.ZAdd(kvAllKey, strconv.FormatInt(st.ChatID, 10), 0)
+}
+
+// saveSecret сохраняет пароль календаря в ключ чата. Настройки без пароля
+// (например, из ListAll) сохранённый пароль не стирают — его удаляет только
+// отключение календаря.
+func (s *kvStorage) saveSecret(st Settings) error {
+	switch {
+	case st.CalDAV == nil:
+		if err := s.store.Delete(secretKey(st.ChatID)); err != nil && !errors.Is(err, kv.ErrNotFound) {
+			return err
+		}
+		return nil
+	case st.CalDAV.Password != "":
+		return s.store.Set(secretKey(st.ChatID), []byte(st.CalDAV.Password))
+	}
+	return nil
+}
+
+// ListAll читает настройки всех чатов из kvAllKey одним MGet
+func (s *kvStorage) ListAll() ([]Settings, error) {
+	ids, err := s.store.ZRange(kvAllKey, kv.MaxScore, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, len(ids))
+	for i, item := range ids {
+		keys[i] = kvPrefix + item.Member
+	}
+	values, err := s.store.MGet(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]Settings, 0, len(values))
+	for i, raw := range values {
+		if raw == nil {
+			continue
+		}
+		var st Settings
+		if err := json.Unmarshal(raw, &st); err != nil {
+			log.Printf("Не удалось прочитать настройки %s: %v", keys[i], err)
+			continue
+		}
+		list = append(list, st)
+	}
+	return list, nil
+}
+
+func userKey(username string) string {
+	return kvUserPrefix + strings.ToLower(username)
+}
+
+func (s *kvStorage) FindUser(username string) (User, bool, error) {
+	raw, err := s.store.Get(userKey(username))
+	if errors.Is(err, kv.ErrNotFound) {
+		return User{}, false, nil
+	}
+	if err != nil {
+		return User{}, false, err
+	}
+
+	var u User
+	if err := json.Unmarshal(raw, &u); err != nil {
+		return User{}, false, err
+	}
+	return u, true, nil
+}
+
+// RememberUser записывает пользователя без блокировки: запись меняется редко
+// (новый @username или первое сообщение в личке), а гонка двух экземпляров
+// в худшем случае отложит отметку Private до следующего запуска бота
+func (s *kvStorage) RememberUser(username string, u User) error {
+	old, ok, err := s.FindUser(username)
+	if err != nil {
+		return err
+	}
+	u = u.merge(old, ok)
+	if ok && old == u {
+		return nil
+	}
+
+	raw, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	return s.store.Set(userKey(username), raw)
+}
+
+// Close ничего не делает: каждое изменение записывается сразу
+func (s *kvStorage) Close() error {
+	return nil
+}
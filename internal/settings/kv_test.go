@@ -0,0 +1,173 @@
+package settings
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"tg-bot/internal/kv"
+)
+
+func newTestStore(t *testing.T) kv.Store {
+	t.Helper()
+	store, err := kv.NewFileStore(filepath.Join(t.TempDir(), "kv.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestKVStoragePasswordKeptApart(t *testing.T) {
+	store := newTestStore(t)
+	s := NewKVStorage(store)
+
+	err := s.Save(Settings{ChatID: 1, CalDAV: &CalDAV{URL: "https://cal.example/", Username: "u", Password: "secret"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := store.Get(settingsKey(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), "secret") {
+		t.Errorf("пароль попал в ключ настроек: %s", raw)
+	}
+
+	got, err := s.Get(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.CalDAV == nil || got.CalDAV.Password != "secret" {
+		t.Errorf("Get вернул %+v, ожидался пароль secret", got.CalDAV)
+	}
+
+	all, err := s.ListAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || all[0].CalDAV == nil || all[0].CalDAV.Password != "" {
+		t.Errorf("ListAll вернул пароль: %+v", all)
+	}
+
+	// Настройки из ListAll сохраняются без пароля, но не стирают его
+	if err := s.Save(all[0]); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.Get(1); got.CalDAV.Password != "secret" {
+		t.Errorf("пароль стёрт сохранением настроек без него")
+	}
+
+	// Отключение календаря удаляет пароль
+	if err := s.Save(Settings{ChatID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(secretKey(1)); err != kv.ErrNotFound {
+		t.Errorf("пароль отключённого календаря не удалён: %v", err)
+	}
+}
+
+func TestKVStorageKeysByChat(t *testing.T) {
+	store := newTestStore(t)
+	s := NewKVStorage(store)
+
+	for _, id := range []int64{1, -100} {
+		if err := s.Update(id, func(st *Settings) { st.Brief = true }); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, id := range []int64{1, -100} {
+		if _, err := store.Get(settingsKey(id)); err != nil {
+			t.Errorf("нет ключа настроек чата %d: %v", id, err)
+		}
+	}
+
+	// Блокировка одного чата не мешает менять другой
+	if ok, err := store.TryLock(lockKey(1), kvLockTTL); err != nil || !ok {
+		t.Fatalf("TryLock: %v, %v", ok, err)
+	}
+	if err := s.Update(-100, func(st *Settings) { st.AirAlert = 150 }); err != nil {
+		t.Fatalf("изменение чата -100 при заблокированном чате 1: %v", err)
+	}
+
+	all, err := s.ListAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	byID := make(map[int64]Settings)
+	for _, st := range all {
+		byID[st.ChatID] = st
+	}
+	if len(all) != 2 || !byID[1].Brief || byID[-100].AirAlert != 150 {
+		t.Errorf("ListAll = %+v", all)
+	}
+}
+
+// countingStore считает записи в kv.Store
+type countingStore struct {
+	kv.Store
+	sets int
+}
+
+func (s *countingStore) Set(key string, value []byte) error {
+	s.sets++
+	return s.Store.Set(key, value)
+}
+
+func TestKVStorageRememberUser(t *testing.T) {
+	store := &countingStore{Store: newTestStore(t)}
+	s := NewKVStorage(store)
+
+	if err := s.RememberUser("Ann", User{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if u, ok, err := s.FindUser("ANN"); err != nil || !ok || u != (User{ID: 1}) {
+		t.Fatalf("FindUser = %+v, %v, %v", u, ok, err)
+	}
+	if _, ok, _ := s.FindUser("bob"); ok {
+		t.Error("FindUser нашёл незнакомого пользователя")
+	}
+
+	// Запись меняется, только если изменилась; личный чат не забывается
+	s.RememberUser("ann", User{ID: 1})
+	s.RememberUser("ann", User{ID: 1, Private: true})
+	s.RememberUser("ann", User{ID: 1})
+	if store.sets != 2 {
+		t.Errorf("записей %d, ожидалось 2", store.sets)
+	}
+	if u, _, _ := s.FindUser("ann"); !u.Private {
+		t.Error("сообщение в группе сбросило отметку личного чата")
+	}
+
+	// Имя перешло к другому пользователю
+	s.RememberUser("ann", User{ID: 2})
+	if u, _, _ := s.FindUser("ann"); u != (User{ID: 2}) {
+		t.Errorf("FindUser = %+v, ожидался новый владелец имени", u)
+	}
+}
+
+func TestKVStorageUpdateIsAtomic(t *testing.T) {
+	s := NewKVStorage(newTestStore(t))
+
+	const n = 20
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.Update(1, func(st *Settings) { st.AirAlert++ }); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := s.Get(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AirAlert != n {
+		t.Errorf("после %d изменений AirAlert = %d: часть изменений потеряна", n, got.AirAlert)
+	}
+}
@@ -0,0 +1,218 @@
+package settings
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"tg-bot/internal/advice"
+)
+
+// Location — сохранённое пользователем местоположение
+type Location struct {
+	Lat float64
+	Lon float64
+}
+
+// CalDAV — подключение к календарю для синхронизации напоминаний
+type CalDAV struct {
+	URL      string    // адрес коллекции календаря
+	Username string
+	Password string    `json:"-"` // пароль приложения; хранится отдельно от настроек и не попадает в ListAll
+	LastSync time.Time // время последней успешной синхронизации
+	LastErr  string    // ошибка последней синхронизации
+	Linked   map[string]string // href → ETag объектов, связанных с напоминаниями на момент последней синхронизации
+}
+
+// Settings — настройки конкретного чата
+type Settings struct {
+	ChatID     int64
+	Location   *Location // nil — используется местоположение по умолчанию
+	Brief      bool      // подписка на утреннюю сводку
+	BriefAir   bool      // добавлять в сводку качество воздуха
+	BriefSent  string    // день ГГГГ-ММ-ДД (по часовому поясу чата), за который сводка уже отправлена
+	AirAlert   int       // порог AQI для оповещения, 0 — оповещение выключено
+	AirAlerted bool      // оповещение уже отправлено, повторно — только после улучшения
+	Advice     *advice.Thresholds // пороги советов по погоде, nil — пороги по умолчанию
+	CalDAV     *CalDAV            // календарь для синхронизации напоминаний, nil — не подключён
+
+	Language  string // язык интерфейса: ru, en, be; пусто — DefaultLanguage
+	TimeZone  string // часовой пояс IANA, например Europe/Minsk; пусто — часовой пояс бота
+	Units     string // система единиц: metric или imperial; пусто — DefaultUnits
+	Currency  string // валюта, в которой показываются курсы; пусто — DefaultCurrency
+	BriefTime string // время утренней сводки ЧЧ:ММ; пусто — DefaultBriefTime
+}
+
+// Значения настроек по умолчанию
+const (
+	DefaultLanguage  = "ru"
+	DefaultUnits     = "metric"
+	DefaultCurrency  = "BYN"
+	DefaultBriefTime = "08:00"
+)
+
+// Lang возвращает язык интерфейса с учётом значения по умолчанию
+func (s Settings) Lang() string {
+	if s.Language == "" {
+		return DefaultLanguage
+	}
+	return s.Language
+}
+
+// UnitSystem возвращает систему единиц с учётом значения по умолчанию
+func (s Settings) UnitSystem() string {
+	if s.Units == "" {
+		return DefaultUnits
+	}
+	return s.Units
+}
+
+// BaseCurrency возвращает базовую валюту с учётом значения по умолчанию
+func (s Settings) BaseCurrency() string {
+	if s.Currency == "" {
+		return DefaultCurrency
+	}
+	return s.Currency
+}
+
+// BriefAt возвращает время утренней сводки с учётом значения по умолчанию
+func (s Settings) BriefAt() string {
+	if s.BriefTime == "" {
+		return DefaultBriefTime
+	}
+	return s.BriefTime
+}
+
+// TimeLocation возвращает часовой пояс чата; fallback — если он не задан или неизвестен
+func (s Settings) TimeLocation(fallback *time.Location) *time.Location {
+	if s.TimeZone == "" {
+		return fallback
+	}
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return fallback
+	}
+	return loc
+}
+
+// AdviceThresholds возвращает пороги советов с учётом значений по умолчанию
+func (s Settings) AdviceThresholds() advice.Thresholds {
+	if s.Advice == nil {
+		return advice.DefaultThresholds()
+	}
+	return *s.Advice
+}
+
+// withoutSecrets возвращает копию настроек без пароля календаря:
+// ListAll нужен рассылкам, и пароли туда попадать не должны
+func withoutSecrets(s Settings) Settings {
+	if s.CalDAV != nil {
+		cal := *s.CalDAV
+		cal.Password = ""
+		s.CalDAV = &cal
+	}
+	return s
+}
+
+// User — пользователь, которого бот видел в чатах: по его @username ставят напоминания другие
+type User struct {
+	ID      int64
+	Private bool // писал боту в личные сообщения: ему можно доставлять напоминания «лично»
+}
+
+// merge возвращает запись u с учётом прежней old: личный чат с ботом не забывается
+func (u User) merge(old User, known bool) User {
+	if known && old.ID == u.ID && old.Private {
+		u.Private = true
+	}
+	return u
+}
+
+type Storage interface {
+	Get( chatID int64 ) ( Settings, error ) // настройки чата (по умолчанию, если ещё не сохранялись)
+	Save( s Settings ) error                // сохранить настройки чата
+	Update( chatID int64, change func( s *Settings ) ) error // прочитать, изменить и сохранить настройки атомарно
+	ListAll() ( []Settings, error )         // все сохранённые настройки без паролей (для рассылок)
+	FindUser( username string ) ( User, bool, error ) // пользователь по @username (без @, регистр не важен)
+	RememberUser( username string, u User ) error     // запомнить @username пользователя; пишет, только если запись изменилась
+	Close() error                           // сбросить несохранённые данные перед остановкой
+}
+
+type memoryStorage struct {
+	mu       sync.Mutex
+	settings map[int64]Settings
+	users    map[string]User // @username в нижнем регистре → пользователь
+}
+
+// NewMemoryStorage создаёт новый экземпляр in-memory хранилища настроек
+func NewMemoryStorage() Storage {
+	return &memoryStorage{
+		settings: make(map[int64]Settings),
+		users:    make(map[string]User),
+	}
+}
+
+func (m *memoryStorage) Get(chatID int64) (Settings, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.settings[chatID]
+	if !ok {
+		return Settings{ChatID: chatID}, nil
+	}
+	return s, nil
+}
+
+func (m *memoryStorage) Save(s Settings) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.settings[s.ChatID] = s
+	return nil
+}
+
+func (m *memoryStorage) Update(chatID int64, change func(s *Settings)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.settings[chatID]
+	if !ok {
+		s = Settings{ChatID: chatID}
+	}
+	change(&s)
+	m.settings[chatID] = s
+	return nil
+}
+
+func (m *memoryStorage) ListAll() ([]Settings, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	all := make([]Settings, 0, len(m.settings))
+	for _, s := range m.settings {
+		all = append(all, withoutSecrets(s))
+	}
+	return all, nil
+}
+
+func (m *memoryStorage) FindUser(username string) (User, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[strings.ToLower(username)]
+	return u, ok, nil
+}
+
+func (m *memoryStorage) RememberUser(username string, u User) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := strings.ToLower(username)
+	old, ok := m.users[key]
+	m.users[key] = u.merge(old, ok)
+	return nil
+}
+
+// Close ничего не делает: in-memory хранилищу нечего сбрасывать
+func (m *memoryStorage) Close() error {
+	return nil
+}